@@ -0,0 +1,332 @@
+package mapper
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type typePair struct {
+	source reflect.Type
+	target reflect.Type
+}
+
+// Config holds the programmatic mapping configuration of type pairs. It lets you configure types you can't add
+// `mapper` tags to (e.g. third-party or generated code), and it takes precedence over tags when both exist.
+type Config struct {
+	mu       sync.RWMutex
+	typeMaps map[typePair]*TypeMap
+}
+
+// NewConfig returns an empty configuration
+func NewConfig() *Config {
+	return &Config{typeMaps: make(map[typePair]*TypeMap)}
+}
+
+// DefaultConfig is the configuration used by Map, MapContext and MapWithConverters
+var DefaultConfig = defaultMapper.Config()
+
+// TypeMap configures how the fields of a source type are mapped into a target type
+type TypeMap struct {
+	config  *Config
+	source  reflect.Type
+	target  reflect.Type
+	fields  map[string][]tagSetting
+	ignored map[string]bool
+	setters []setterCall
+}
+
+// setterCall maps a source field by calling a setter method of the target, e.g. for reversed fromMethod fields
+type setterCall struct {
+	field  string
+	method string
+}
+
+// For returns the configuration for mapping Src values into Dst values, creating it if needed. E.g:
+//
+//	mapper.For[User, UserDTO](cfg).
+//		Field("FullName", mapper.FromMethod("GetFullName")).
+//		Ignore("Password")
+func For[Src, Dst any](cfg *Config) *TypeMap {
+	source := indirectType(reflect.TypeOf((*Src)(nil)).Elem())
+	target := indirectType(reflect.TypeOf((*Dst)(nil)).Elem())
+	if target.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("mapper: cannot configure %v, the target must be a struct", target))
+	}
+
+	return cfg.typeMap(source, target)
+}
+
+func (c *Config) typeMap(source, target reflect.Type) *TypeMap {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	pair := typePair{source, target}
+	if typeMap, ok := c.typeMaps[pair]; ok {
+		return typeMap
+	}
+
+	typeMap := &TypeMap{
+		config:  c,
+		source:  source,
+		target:  target,
+		fields:  make(map[string][]tagSetting),
+		ignored: make(map[string]bool),
+	}
+	c.typeMaps[pair] = typeMap
+	return typeMap
+}
+
+// Field configures how the target field with the given name is mapped. The options replace
+// the `mapper` tag of the field (if any), e.g:
+//
+//	Field("FirstName", FromField("Name"), Transform("trim", "title"))
+//
+// is equivalent to `mapper:"fromField:Name;transform:trim,title"`.
+// Nested target fields can be configured with a dotted path, e.g. `Field("Address.City", FromField("City"))`.
+func (m *TypeMap) Field(name string, opts ...FieldOption) *TypeMap {
+	m.checkField(name)
+
+	settings := make([]tagSetting, 0, len(opts))
+	for _, opt := range opts {
+		settings = append(settings, opt.setting)
+	}
+
+	m.config.mu.Lock()
+	defer m.config.mu.Unlock()
+	m.fields[name] = settings
+	delete(m.ignored, name)
+	return m
+}
+
+// Ignore leaves the target fields with the given names untouched
+func (m *TypeMap) Ignore(names ...string) *TypeMap {
+	for _, name := range names {
+		m.checkField(name)
+	}
+
+	m.config.mu.Lock()
+	defer m.config.mu.Unlock()
+	for _, name := range names {
+		m.ignored[name] = true
+	}
+	return m
+}
+
+func (m *TypeMap) checkField(name string) {
+	if !hasFieldPath(m.target, name) {
+		panic(fmt.Sprintf("mapper: field %v not found in %v", name, m.target))
+	}
+}
+
+// hasFieldPath reports whether the struct type has the field with the given (possibly dotted) path
+func hasFieldPath(t reflect.Type, path string) bool {
+	for _, name := range strings.Split(path, ".") {
+		t = indirectType(t)
+		if t.Kind() != reflect.Struct {
+			return false
+		}
+		f, ok := t.FieldByName(name)
+		if !ok {
+			return false
+		}
+		t = f.Type
+	}
+	return true
+}
+
+// ReverseMap configures the reverse mapping, from Dst values into Src values, out of the configuration
+// and the `mapper` tags of this one:
+//   - `fromField` and `toField` renames are inverted, and flattened paths (e.g. `fromField:Address.City`) are unflattened
+//   - `layout`, `unix` and `unixMilli` conversions are kept, since they work both ways, but transforms are dropped
+//   - ignored fields are ignored in reverse too
+//
+// `fromMethod` fields can't be reversed, so they're reported with an ErrIrreversibleField error, unless they're
+// explicitly ignored with ReverseIgnore or given a setter with ReverseSetter. The rest of the fields are
+// configured anyway, and the reverse TypeMap is returned so it can be configured further.
+func (m *TypeMap) ReverseMap() (*TypeMap, error) {
+	if m.source.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("mapper: cannot reverse into %v, the target must be a struct", m.source))
+	}
+
+	reverse := m.config.typeMap(m.target, m.source)
+	var irreversible []string
+	for _, f := range typeFields(m.target).list {
+		if f.embedded {
+			continue
+		}
+
+		settings, ignored := m.fieldSettings(f)
+		if ignored {
+			if hasFieldPath(m.source, f.name) {
+				reverse.Ignore(f.name)
+			}
+			continue
+		}
+
+		var reversed []tagSetting
+		for _, setting := range settings {
+			switch setting.option {
+			case "layout", "unix", "unixMilli":
+				reversed = append(reversed, setting)
+			}
+		}
+
+		from, renamed := lookupTagSetting(settings, "fromField")
+		if !renamed {
+			from, renamed = lookupTagSetting(settings, "toField")
+		}
+		method, hasMethod := lookupTagSetting(settings, "fromMethod")
+		setter, hasSetter := lookupTagSetting(settings, "reverse")
+
+		switch {
+		case hasSetter && setter == "-":
+		case hasSetter:
+			reverse.addSetter(f.name, setter)
+		case hasMethod:
+			irreversible = append(irreversible, fmt.Sprintf("%v (fromMethod:%v)", f.name, method))
+		case renamed && hasFieldPath(m.source, from):
+			reverse.Field(from, append([]FieldOption{FromField(f.name)}, fieldOptions(reversed)...)...)
+		case len(reversed) > 0 && hasFieldPath(m.source, f.name):
+			reverse.Field(f.name, fieldOptions(reversed)...)
+		}
+	}
+
+	if len(irreversible) > 0 {
+		return reverse, fmt.Errorf("%v into %v: %v: %w", m.target, m.source, strings.Join(irreversible, ", "), ErrIrreversibleField)
+	}
+	return reverse, nil
+}
+
+func (m *TypeMap) addSetter(field, method string) {
+	m.config.mu.Lock()
+	defer m.config.mu.Unlock()
+	for i, call := range m.setters {
+		if call.field == field {
+			m.setters[i].method = method
+			return
+		}
+	}
+	m.setters = append(m.setters, setterCall{field: field, method: method})
+}
+
+func fieldOptions(settings []tagSetting) []FieldOption {
+	opts := make([]FieldOption, 0, len(settings))
+	for _, setting := range settings {
+		opts = append(opts, FieldOption{setting})
+	}
+	return opts
+}
+
+func (c *Config) lookup(source, target reflect.Type) *TypeMap {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.typeMaps[typePair{source, target}]
+}
+
+// fieldSettings returns the settings for the target field, taken from the configuration
+// or from the field tag, and whether the field is ignored
+func (m *TypeMap) fieldSettings(f field) ([]tagSetting, bool) {
+	if m != nil {
+		m.config.mu.RLock()
+		settings, configured := m.fields[f.name]
+		ignored := m.ignored[f.name]
+		m.config.mu.RUnlock()
+
+		if ignored || configured {
+			return settings, ignored
+		}
+	}
+
+	return mapperTagSettings(f.structField.Tag), false
+}
+
+// nestedFields returns the settings of the nested target fields configured with a dotted path, sorted by path
+func (m *TypeMap) nestedFields() ([]string, map[string][]tagSetting) {
+	if m == nil {
+		return nil, nil
+	}
+
+	m.config.mu.RLock()
+	defer m.config.mu.RUnlock()
+	var paths []string
+	nested := map[string][]tagSetting{}
+	for name, settings := range m.fields {
+		if strings.Contains(name, ".") && !m.ignored[name] {
+			paths = append(paths, name)
+			nested[name] = settings
+		}
+	}
+	sort.Strings(paths)
+	return paths, nested
+}
+
+// setterCalls returns the source fields mapped by calling a setter method of the target
+func (m *TypeMap) setterCalls() []setterCall {
+	if m == nil {
+		return nil
+	}
+
+	m.config.mu.RLock()
+	defer m.config.mu.RUnlock()
+	return append([]setterCall(nil), m.setters...)
+}
+
+// FieldOption configures how a target field is mapped, like the options of the `mapper` struct tag
+type FieldOption struct {
+	setting tagSetting
+}
+
+// FromField maps the field from the source field with the given name, like the `fromField` tag option
+func FromField(name string) FieldOption {
+	return FieldOption{tagSetting{option: "fromField", value: name}}
+}
+
+// FromMethod maps the field from the result of calling the source method, like the `fromMethod` tag option.
+// Literal arguments can be included, e.g. `FromMethod("FormattedPrice(USD, 2)")`
+func FromMethod(call string) FieldOption {
+	return FieldOption{tagSetting{option: "fromMethod", value: call}}
+}
+
+// ToMethod sets the field by calling the target setter method, like the `toMethod` tag option
+func ToMethod(name string) FieldOption {
+	return FieldOption{tagSetting{option: "toMethod", value: name}}
+}
+
+// Transform runs the source value through the named transforms, like the `transform` tag option.
+// E.g. `Transform("trim", "truncate(10)")`
+func Transform(names ...string) FieldOption {
+	return FieldOption{tagSetting{option: "transform", value: strings.Join(names, ",")}}
+}
+
+// Layout formats or parses times with the given layout, like the `layout` tag option
+func Layout(layout string) FieldOption {
+	return FieldOption{tagSetting{option: "layout", value: layout}}
+}
+
+// Unix converts times into Unix seconds and back, like the `unix` tag option
+func Unix() FieldOption {
+	return FieldOption{tagSetting{option: "unix"}}
+}
+
+// UnixMilli converts times into Unix milliseconds and back, like the `unixMilli` tag option
+func UnixMilli() FieldOption {
+	return FieldOption{tagSetting{option: "unixMilli"}}
+}
+
+// ReverseIgnore leaves the field out of the reverse mapping built by ReverseMap, like the `reverse:-` tag option
+func ReverseIgnore() FieldOption {
+	return FieldOption{tagSetting{option: "reverse", value: "-"}}
+}
+
+// ReverseSetter maps the field in the reverse mapping built by ReverseMap by calling the given setter method
+// of the reverse target, like the `reverse:{MethodName}` tag option
+func ReverseSetter(name string) FieldOption {
+	return FieldOption{tagSetting{option: "reverse", value: name}}
+}
@@ -0,0 +1,46 @@
+package mapper
+
+import (
+	"context"
+	"reflect"
+)
+
+// ContextConverterFn is a TypeConverterFn receiving the context of the mapping (see MapContext)
+type ContextConverterFn func(ctx context.Context, value interface{}) interface{}
+
+// WithContextConverters adds converters for custom types receiving the context of the mapping, e.g. to read
+// the locale of the request. They're registered by the target type name like WithConverters, and replace the
+// converters registered for the same types (and vice versa).
+func WithContextConverters(converters map[string]ContextConverterFn) Option {
+	return func(m *Mapper) {
+		for k, v := range converters {
+			m.contextConverters[k] = v
+			delete(m.converters, k)
+		}
+	}
+}
+
+// converter returns the converter for the target type, if any. The converters given to MapWithConverters
+// take precedence over the ones of the Mapper.
+func (s *mappingState) converter(targetType reflect.Type) (TypeConverterFn, bool) {
+	name := targetType.String()
+	if fn, ok := s.converters[name]; ok {
+		return fn, true
+	}
+	if fn, ok := s.mapper.contextConverters[name]; ok {
+		return func(value interface{}) interface{} {
+			return fn(s.ctx, value)
+		}, true
+	}
+	return nil, false
+}
+
+// checkCanceled returns the error of the context, wrapped with the current path, once it's done
+func (s *mappingState) checkCanceled() error {
+	select {
+	case <-s.ctx.Done():
+		return s.fieldError("mapping canceled", s.ctx.Err())
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,114 @@
+package mapper
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// UnknownEnumPolicy defines what happens when a value is not found in a registered enum table
+type UnknownEnumPolicy int
+
+const (
+	// UnknownEnumError fails the mapping with an ErrUnknownEnumValue error
+	UnknownEnumError UnknownEnumPolicy = iota
+	// UnknownEnumZero leaves the target with its zero value
+	UnknownEnumZero
+	// UnknownEnumPassthrough maps the value as if no enum table was registered (e.g. Status(7) becomes "7")
+	UnknownEnumPassthrough
+)
+
+type enumKey struct {
+	source reflect.Type
+	target reflect.Type
+}
+
+type enumTable struct {
+	values reflect.Value
+	policy UnknownEnumPolicy
+}
+
+// RegisterEnum registers a table to map enum values in both directions, e.g:
+//
+//	RegisterEnum(map[Status]string{StatusActive: "active", StatusBlocked: "blocked"})
+//
+// maps Status values into strings, and strings into Status values.
+// Values not found in the table produce an ErrUnknownEnumValue error.
+func RegisterEnum(table interface{}) {
+	defaultMapper.RegisterEnumWithPolicy(table, UnknownEnumError)
+}
+
+// RegisterEnumWithPolicy registers a table to map enum values in both directions,
+// and the policy applied to values not found in the table
+func RegisterEnumWithPolicy(table interface{}, policy UnknownEnumPolicy) {
+	defaultMapper.RegisterEnumWithPolicy(table, policy)
+}
+
+// RegisterEnum registers a table to map enum values in both directions (see the RegisterEnum function)
+func (m *Mapper) RegisterEnum(table interface{}) {
+	m.RegisterEnumWithPolicy(table, UnknownEnumError)
+}
+
+// RegisterEnumWithPolicy registers a table to map enum values in both directions,
+// and the policy applied to values not found in the table
+func (m *Mapper) RegisterEnumWithPolicy(table interface{}, policy UnknownEnumPolicy) {
+	forward := reflect.ValueOf(table)
+	if forward.Kind() != reflect.Map {
+		panic(fmt.Sprintf("mapper: enum table must be a map, got %T", table))
+	}
+
+	keyType, elemType := forward.Type().Key(), forward.Type().Elem()
+	reverse := reflect.MakeMapWithSize(reflect.MapOf(elemType, keyType), forward.Len())
+	iter := forward.MapRange()
+	for iter.Next() {
+		if reverse.MapIndex(iter.Value()).IsValid() {
+			panic(fmt.Sprintf("mapper: duplicated enum value %v in %T", iter.Value(), table))
+		}
+		reverse.SetMapIndex(iter.Value(), iter.Key())
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.enumTables[enumKey{keyType, elemType}] = enumTable{values: forward, policy: policy}
+	m.enumTables[enumKey{elemType, keyType}] = enumTable{values: reverse, policy: policy}
+}
+
+func (m *Mapper) lookupEnumTable(sourceType, targetType reflect.Type) (enumTable, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	table, ok := m.enumTables[enumKey{sourceType, targetType}]
+	return table, ok
+}
+
+// mapEnum maps the source value using a registered enum table, if any. It returns false when
+// there's no table for the source and target types, or the value should be mapped as usual
+func mapEnum(sourceValue, targetValue reflect.Value, state *mappingState) (interface{}, bool, error) {
+	if sourceValue.Kind() == reflect.Ptr && !sourceValue.IsNil() {
+		sourceValue = sourceValue.Elem()
+	}
+	if !sourceValue.IsValid() {
+		return nil, false, nil
+	}
+
+	table, ok := state.mapper.lookupEnumTable(sourceValue.Type(), targetValue.Type())
+	if !ok {
+		return nil, false, nil
+	}
+
+	value := table.values.MapIndex(sourceValue)
+	if !value.IsValid() {
+		switch table.policy {
+		case UnknownEnumZero:
+			value = reflect.Zero(targetValue.Type())
+		case UnknownEnumPassthrough:
+			return nil, false, nil
+		default:
+			return nil, true, fmt.Errorf("%v %v into %v: %w", sourceValue.Type(), sourceValue.Interface(), targetValue.Type(), ErrUnknownEnumValue)
+		}
+	}
+
+	if targetValue.CanSet() {
+		targetValue.Set(value)
+	}
+
+	return value.Interface(), true, nil
+}
@@ -10,6 +10,20 @@ var (
 	ErrUnexpectedNil = errors.New("should not be nil")
 	// ErrMustBePointer must be a pointer
 	ErrMustBePointer = errors.New("must be a pointer")
+	// ErrNoConcreteType no concrete type registered for interface
+	ErrNoConcreteType = errors.New("no concrete type registered for interface")
+	// ErrUnknownDiscriminator no concrete type registered for discriminator value
+	ErrUnknownDiscriminator = errors.New("no concrete type registered for discriminator value")
+	// ErrUnknownEnumValue value not found in enum table
+	ErrUnknownEnumValue = errors.New("value not found in enum table")
+	// ErrUnknownTransform no transform registered with that name
+	ErrUnknownTransform = errors.New("no transform registered with that name")
+	// ErrMissingSourceField no source field or method found for the target field (strict mode)
+	ErrMissingSourceField = errors.New("no source field found for the target field")
+	// ErrAmbiguousField more than one source field matches the target field
+	ErrAmbiguousField = errors.New("more than one source field matches the target field")
+	// ErrIrreversibleField the field mapping can't be reversed
+	ErrIrreversibleField = errors.New("field mapping can't be reversed")
 )
 
 // FieldError is produced at run-time while mapping values from one struct to another
@@ -20,9 +34,23 @@ type FieldError struct {
 }
 
 func (e *FieldError) Error() string {
+	if e.fieldName == "" {
+		return fmt.Sprintf("Invalid target\n%v\n%v", e.context, e.err.Error())
+	}
 	return fmt.Sprintf("Invalid field: %v\n%v\n%v", e.fieldName, e.context, e.err.Error())
 }
 
+// Path returns the path of the invalid field from the target root, e.g. "Children[0].Name".
+// It's empty when the error is produced at the target root.
+func (e *FieldError) Path() string {
+	return e.fieldName
+}
+
+// Unwrap returns the underlying error, so FieldError works with errors.Is and errors.As
+func (e *FieldError) Unwrap() error {
+	return e.err
+}
+
 func newFieldError(fieldName, context string, err error) *FieldError {
 	return &FieldError{
 		fieldName: fieldName,
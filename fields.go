@@ -0,0 +1,344 @@
+package mapper
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// field is a struct field reachable from a struct type, either declared directly on it
+// or promoted from an embedded struct (or pointer to struct)
+type field struct {
+	name        string
+	index       []int
+	typ         reflect.Type
+	structField reflect.StructField
+	// tagged reports whether the field carries a `mapper` struct tag
+	tagged bool
+	// embedded reports whether the field is an embedded struct whose fields are promoted. Embedded structs
+	// without exported fields (e.g. time.Time) are ordinary fields.
+	embedded bool
+}
+
+// structFields holds the visible fields of a struct type and an index by name
+type structFields struct {
+	list   []field
+	byName map[string]int
+}
+
+// cachedTypeFields is like typeFields but uses the Mapper cache to avoid repeated work
+func (m *Mapper) cachedTypeFields(t reflect.Type) *structFields {
+	if f, ok := m.fieldCache.Load(t); ok {
+		return f.(*structFields)
+	}
+	f, _ := m.fieldCache.LoadOrStore(t, typeFields(t))
+	return f.(*structFields)
+}
+
+// typeFields returns the fields visible from the given struct type, following Go's field promotion rules
+// for embedded structs. Ambiguities are resolved the same way encoding/json does:
+//   - fields at a shallower depth hide the ones at deeper levels
+//   - among fields at the same depth, those with a `mapper` tag win over untagged ones
+//   - if more than one field remains, the name is ambiguous and all of them are dropped
+func typeFields(t reflect.Type) *structFields {
+	type embedding struct {
+		typ   reflect.Type
+		index []int
+	}
+
+	var candidates []field
+	current := []embedding{}
+	next := []embedding{{typ: t}}
+	visited := map[reflect.Type]bool{}
+
+	for len(next) > 0 {
+		current, next = next, nil
+		levelVisited := map[reflect.Type]bool{}
+
+		for _, e := range current {
+			// Types already expanded at a shallower depth are hidden by those fields
+			if visited[e.typ] {
+				continue
+			}
+			levelVisited[e.typ] = true
+
+			for i := 0; i < e.typ.NumField(); i++ {
+				sf := e.typ.Field(i)
+				index := make([]int, len(e.index)+1)
+				copy(index, e.index)
+				index[len(e.index)] = i
+
+				_, tagged := sf.Tag.Lookup("mapper")
+				ft := sf.Type
+				if sf.Anonymous && ft.Kind() == reflect.Ptr {
+					ft = ft.Elem()
+				}
+
+				if sf.Anonymous && ft.Kind() == reflect.Struct && !tagged {
+					// Pointers to unexported struct types can't be allocated, so they're ignored
+					if !sf.IsExported() && sf.Type.Kind() == reflect.Ptr {
+						continue
+					}
+					if sf.IsExported() {
+						candidates = append(candidates, field{
+							name: sf.Name, index: index, typ: sf.Type, structField: sf, embedded: true,
+						})
+					}
+					next = append(next, embedding{typ: ft, index: index})
+					continue
+				}
+
+				if !sf.IsExported() {
+					continue
+				}
+
+				candidates = append(candidates, field{
+					name: sf.Name, index: index, typ: sf.Type, structField: sf, tagged: tagged,
+				})
+			}
+		}
+
+		for typ := range levelVisited {
+			visited[typ] = true
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.name != b.name {
+			return a.name < b.name
+		}
+		if len(a.index) != len(b.index) {
+			return len(a.index) < len(b.index)
+		}
+		return a.tagged && !b.tagged
+	})
+
+	fields := make([]field, 0, len(candidates))
+	for i := 0; i < len(candidates); {
+		j := i + 1
+		for j < len(candidates) && candidates[j].name == candidates[i].name {
+			j++
+		}
+		if dominant, ok := dominantField(candidates[i:j]); ok {
+			fields = append(fields, dominant)
+		}
+		i = j
+	}
+
+	// Keep the declaration order
+	sort.Slice(fields, func(i, j int) bool {
+		a, b := fields[i].index, fields[j].index
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return len(a) < len(b)
+	})
+
+	byName := make(map[string]int, len(fields))
+	for i, f := range fields {
+		byName[f.name] = i
+		if f.embedded && !promotesFields(f, fields) {
+			fields[i].embedded = false
+		}
+	}
+
+	return &structFields{list: fields, byName: byName}
+}
+
+// promotesFields reports whether any of the fields is promoted from the embedded field
+func promotesFields(embedded field, fields []field) bool {
+	for _, f := range fields {
+		if isWithin(f.index, embedded.index) {
+			return true
+		}
+	}
+	return false
+}
+
+// isWithin reports whether the field index is nested in the given (embedded) field index
+func isWithin(index, embedded []int) bool {
+	if len(index) <= len(embedded) {
+		return false
+	}
+	for i, x := range embedded {
+		if index[i] != x {
+			return false
+		}
+	}
+	return true
+}
+
+// dominantField picks the field that hides all others sharing its name, if any.
+// The fields must be sorted by depth, with tagged fields first at each depth.
+func dominantField(fields []field) (field, bool) {
+	if len(fields) > 1 && len(fields[0].index) == len(fields[1].index) && fields[0].tagged == fields[1].tagged {
+		return field{}, false
+	}
+	return fields[0], true
+}
+
+// fieldByName returns the (possibly promoted) field with the given name, or an invalid Value
+// if the field does not exist, is ambiguous or is only reachable through a nil embedded pointer
+func (m *Mapper) fieldByName(structValue reflect.Value, name string) reflect.Value {
+	if structValue.Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+
+	return lookupField(structValue, m.cachedTypeFields(structValue.Type()), name)
+}
+
+// fieldNamed returns the (possibly promoted) field of the struct type with the given name
+func (m *Mapper) fieldNamed(t reflect.Type, name string) (field, bool) {
+	fields := m.cachedTypeFields(t)
+	i, ok := fields.byName[name]
+	if !ok {
+		return field{}, false
+	}
+	return fields.list[i], true
+}
+
+// fieldByPath returns the field with the given dotted path (e.g. `Address.City`), following pointers.
+// It returns an invalid Value if any of the fields does not exist or is only reachable through a nil pointer
+func (m *Mapper) fieldByPath(structValue reflect.Value, path string) reflect.Value {
+	v := structValue
+	for i, name := range strings.Split(path, ".") {
+		if i > 0 {
+			if v.Kind() == reflect.Ptr && v.IsNil() {
+				return reflect.Value{}
+			}
+			v = reflect.Indirect(v)
+		}
+		if v = m.fieldByName(v, name); !v.IsValid() {
+			return v
+		}
+	}
+	return v
+}
+
+// matchFieldByName is like fieldByName, but the name is the one used to match fields (see matchFields)
+func (m *Mapper) matchFieldByName(structValue reflect.Value, name string) reflect.Value {
+	if structValue.Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+
+	return lookupField(structValue, m.matchFields(structValue.Type()), name)
+}
+
+func lookupField(structValue reflect.Value, fields *structFields, name string) reflect.Value {
+	i, ok := fields.byName[name]
+	if !ok {
+		return reflect.Value{}
+	}
+
+	return fieldByIndex(structValue, fields.list[i].index, false)
+}
+
+// matchFields returns the fields of a source struct type named after the match tag of the Mapper (see WithMatchTags),
+// or the fields of the type if there's no match tag. Fields ignored with `-` are left out, and names
+// used by more than one field can't be looked up.
+func (m *Mapper) matchFields(t reflect.Type) *structFields {
+	if m.sourceTag == "" {
+		return m.cachedTypeFields(t)
+	}
+	if f, ok := m.matchCache.Load(t); ok {
+		return f.(*structFields)
+	}
+
+	typeFields := m.cachedTypeFields(t)
+	fields := &structFields{list: make([]field, 0, len(typeFields.list)), byName: make(map[string]int)}
+	duplicated := map[string]bool{}
+	for _, f := range typeFields.list {
+		name, ok := tagName(f.structField, m.sourceTag)
+		if !ok {
+			continue
+		}
+		if _, ok := fields.byName[name]; ok {
+			duplicated[name] = true
+		}
+		f.name = name
+		fields.byName[name] = len(fields.list)
+		fields.list = append(fields.list, f)
+	}
+	for name := range duplicated {
+		delete(fields.byName, name)
+	}
+
+	f, _ := m.matchCache.LoadOrStore(t, fields)
+	return f.(*structFields)
+}
+
+// renamedSourceFields returns the source fields renamed with their own mapper tags, indexed by the target field name:
+// a source field tagged with `toField:X` is mapped into the target field X. Source fields tagged with `fromField`
+// describe how the source is mapped from another type, so they're only reversed by ReverseMap.
+func (m *Mapper) renamedSourceFields(t reflect.Type) map[string]namingMatch {
+	if f, ok := m.renameCache.Load(t); ok {
+		return f.(map[string]namingMatch)
+	}
+
+	renames := map[string]namingMatch{}
+	for _, f := range m.cachedTypeFields(t).list {
+		for _, setting := range mapperTagSettings(f.structField.Tag) {
+			if setting.option != "toField" || setting.value == "" {
+				continue
+			}
+			if previous, ok := renames[setting.value]; ok && previous.name != f.name {
+				renames[setting.value] = namingMatch{err: fmt.Errorf("%v is the target of %v and %v in %v: %w", setting.value, previous.name, f.name, t, ErrAmbiguousField)}
+				continue
+			}
+			renames[setting.value] = namingMatch{name: f.name}
+		}
+	}
+
+	f, _ := m.renameCache.LoadOrStore(t, renames)
+	return f.(map[string]namingMatch)
+}
+
+// targetMatchName returns the name used to find the source of a target field: the value of the match tag
+// of the Mapper, or the Go name if there's no match tag. It returns false if the field is ignored with `-`.
+func (m *Mapper) targetMatchName(f field) (string, bool) {
+	if m.targetTag == "" {
+		return f.structField.Name, true
+	}
+	return tagName(f.structField, m.targetTag)
+}
+
+// sourceMatchName returns the name of the source field matching the target field: its match name (see targetMatchName),
+// or its Go name when the source has no field with the match name, e.g. untagged sources matched by tag.
+// It returns false if the source has no field with either name.
+func (m *Mapper) sourceMatchName(sourceType reflect.Type, f field) (string, bool) {
+	name, _ := m.targetMatchName(f)
+	fields := m.matchFields(sourceType)
+	if _, ok := fields.byName[name]; ok {
+		return name, true
+	}
+	if goName := f.structField.Name; goName != name {
+		if _, ok := fields.byName[goName]; ok {
+			return goName, true
+		}
+	}
+	return name, false
+}
+
+// fieldByIndex walks the index path through embedded structs. When a nil embedded pointer is found
+// it's allocated if alloc is true, otherwise an invalid Value is returned
+func fieldByIndex(structValue reflect.Value, index []int, alloc bool) reflect.Value {
+	v := structValue
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !alloc || !v.CanSet() {
+					return reflect.Value{}
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+
+	return v
+}
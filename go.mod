@@ -1,12 +1,14 @@
 module github.com/agustinaliagac/mapper
 
-go 1.17
+go 1.21
 
-require github.com/stretchr/testify v1.7.0
+require (
+	github.com/fatih/structtag v1.2.0
+	github.com/stretchr/testify v1.7.0
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.0 // indirect
-	github.com/fatih/structtag v1.2.0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c // indirect
 )
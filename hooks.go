@@ -0,0 +1,48 @@
+package mapper
+
+import "reflect"
+
+// BeforeMapper is implemented by targets that need to run some logic before their fields are mapped.
+// It receives the source value the target is mapped from. Returning an error aborts the mapping.
+type BeforeMapper interface {
+	BeforeMap(source interface{}) error
+}
+
+// AfterMapper is implemented by targets that need to run some logic after their fields are mapped,
+// e.g. to compute derived fields or validate themselves.
+// It receives the source value the target is mapped from. Returning an error aborts the mapping.
+type AfterMapper interface {
+	AfterMap(source interface{}) error
+}
+
+// hookReceiver returns the target value (or a pointer to it, so that the hook can modify it) implementing the hook
+func hookReceiver(targetValue reflect.Value) interface{} {
+	if targetValue.CanAddr() {
+		return targetValue.Addr().Interface()
+	}
+	if targetValue.CanInterface() {
+		return targetValue.Interface()
+	}
+	return nil
+}
+
+func hookSource(sourceValue reflect.Value) interface{} {
+	if !sourceValue.IsValid() || !sourceValue.CanInterface() {
+		return nil
+	}
+	return sourceValue.Interface()
+}
+
+func callBeforeMap(targetValue, sourceValue reflect.Value) error {
+	if hook, ok := hookReceiver(targetValue).(BeforeMapper); ok {
+		return hook.BeforeMap(hookSource(sourceValue))
+	}
+	return nil
+}
+
+func callAfterMap(targetValue, sourceValue reflect.Value) error {
+	if hook, ok := hookReceiver(targetValue).(AfterMapper); ok {
+		return hook.AfterMap(hookSource(sourceValue))
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+package mapper
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"runtime/metrics"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// Instrumentation is notified once each top-level mapping (Map, MapContext, etc.) is done, and once each nested
+// type pair mapping is done when WithNestedInstrumentation is set. It must be safe for concurrent use.
+type Instrumentation interface {
+	ObserveMapping(ctx context.Context, metrics MappingMetrics)
+}
+
+// MappingMetrics describes a mapping of a type pair
+type MappingMetrics struct {
+	Pair TypePair
+	// Path is the path of the target value, empty for top-level mappings
+	Path     string
+	Nested   bool
+	Duration time.Duration
+	// Elements is the number of slice elements and map entries mapped
+	Elements int
+	// Allocs is the number of heap objects allocated by the process during the mapping. It's approximate:
+	// the runtime accounts allocations in batches, and it includes the allocations of other goroutines,
+	// so it's only meaningful aggregated over many mappings.
+	Allocs uint64
+	Err    error
+}
+
+// WithInstrumentation sets the Instrumentation notified once each top-level mapping of the Mapper is done,
+// e.g. a Collector
+func WithInstrumentation(instrumentation Instrumentation) Option {
+	return func(m *Mapper) {
+		m.instrumentation = instrumentation
+	}
+}
+
+// WithNestedInstrumentation notifies the Instrumentation of the Mapper of each nested struct mapping too
+// (e.g. the elements of a slice), which adds some overhead to every struct mapped
+func WithNestedInstrumentation() Option {
+	return func(m *Mapper) {
+		m.nestedInstrumentation = true
+	}
+}
+
+// observation measures a mapping for the Instrumentation of the Mapper
+type observation struct {
+	start    time.Time
+	elements int
+	allocs   uint64
+}
+
+// startObservation starts measuring a mapping, when the Mapper is instrumented
+func (s *mappingState) startObservation() observation {
+	return observation{start: time.Now(), elements: s.elements, allocs: heapAllocs()}
+}
+
+// observe notifies the Instrumentation of the Mapper of the mapping of the type pair
+func (s *mappingState) observe(o observation, pair TypePair, err error) {
+	s.mapper.instrumentation.ObserveMapping(s.ctx, MappingMetrics{
+		Pair:     pair,
+		Path:     s.currentPath(),
+		Nested:   len(s.path) > 0,
+		Duration: time.Since(o.start),
+		Elements: s.elements - o.elements,
+		Allocs:   heapAllocs() - o.allocs,
+		Err:      err,
+	})
+}
+
+// observeNested reports whether nested struct mappings are observed
+func (s *mappingState) observeNested() bool {
+	return s.mapper.instrumentation != nil && s.mapper.nestedInstrumentation && len(s.path) > 0
+}
+
+func mapToObservedStruct(sourceValue, targetValue reflect.Value, state *mappingState) (interface{}, error) {
+	o := state.startObservation()
+	pair := TypePair{Target: targetValue.Type()}
+	if source := reflect.Indirect(sourceValue); source.IsValid() {
+		pair.Source = source.Type()
+	}
+
+	newValue, err := mapToStruct(sourceValue, targetValue, state)
+	state.observe(o, pair, err)
+	return newValue, err
+}
+
+// heapAllocs returns the cumulative count of heap objects allocated by the process
+func heapAllocs() uint64 {
+	sample := [1]metrics.Sample{{Name: "/gc/heap/allocs:objects"}}
+	metrics.Read(sample[:])
+	if sample[0].Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+	return sample[0].Value.Uint64()
+}
+
+// PairSummary aggregates the metrics of the mappings of a type pair
+type PairSummary struct {
+	Pair     TypePair
+	Nested   bool
+	Count    int
+	Errors   int
+	Total    time.Duration
+	Max      time.Duration
+	Elements int
+	Allocs   uint64
+}
+
+// Mean returns the mean duration of the mappings
+func (s PairSummary) Mean() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.Total / time.Duration(s.Count)
+}
+
+type summaryKey struct {
+	pair   TypePair
+	nested bool
+}
+
+// Collector is an Instrumentation that aggregates the metrics of the mappings in memory, per type pair, e.g:
+//
+//	collector := mapper.NewCollector()
+//	m := mapper.New(mapper.WithInstrumentation(collector))
+//	// ...
+//	collector.WriteTo(os.Stderr)
+type Collector struct {
+	mu    sync.Mutex
+	pairs map[summaryKey]*PairSummary
+}
+
+// NewCollector returns an empty Collector
+func NewCollector() *Collector {
+	return &Collector{pairs: make(map[summaryKey]*PairSummary)}
+}
+
+// ObserveMapping adds the metrics of a mapping to the summary of its type pair
+func (c *Collector) ObserveMapping(_ context.Context, metrics MappingMetrics) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := summaryKey{metrics.Pair, metrics.Nested}
+	summary, ok := c.pairs[key]
+	if !ok {
+		summary = &PairSummary{Pair: metrics.Pair, Nested: metrics.Nested}
+		c.pairs[key] = summary
+	}
+	summary.Count++
+	if metrics.Err != nil {
+		summary.Errors++
+	}
+	summary.Total += metrics.Duration
+	if metrics.Duration > summary.Max {
+		summary.Max = metrics.Duration
+	}
+	summary.Elements += metrics.Elements
+	summary.Allocs += metrics.Allocs
+}
+
+// Summary returns the summaries of the type pairs mapped, the slowest ones (by total duration) first
+func (c *Collector) Summary() []PairSummary {
+	c.mu.Lock()
+	summaries := make([]PairSummary, 0, len(c.pairs))
+	for _, summary := range c.pairs {
+		summaries = append(summaries, *summary)
+	}
+	c.mu.Unlock()
+
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Total != summaries[j].Total {
+			return summaries[i].Total > summaries[j].Total
+		}
+		return summaries[i].Pair.String() < summaries[j].Pair.String()
+	})
+	return summaries
+}
+
+// Reset discards the collected metrics
+func (c *Collector) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pairs = make(map[summaryKey]*PairSummary)
+}
+
+// WriteTo writes the summary as a table, e.g:
+//
+//	PAIR                               NESTED  COUNT  ERRORS  TOTAL  MEAN   MAX  ELEMENTS  ALLOCS
+//	[]mapper.User -> []mapper.UserDTO  false   10     0       12ms   1.2ms  3ms  1000      52000
+func (c *Collector) WriteTo(w io.Writer) (int64, error) {
+	var b strings.Builder
+	tw := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PAIR\tNESTED\tCOUNT\tERRORS\tTOTAL\tMEAN\tMAX\tELEMENTS\tALLOCS")
+	for _, s := range c.Summary() {
+		fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n", s.Pair, s.Nested, s.Count, s.Errors, s.Total, s.Mean(), s.Max, s.Elements, s.Allocs)
+	}
+	tw.Flush()
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
@@ -0,0 +1,185 @@
+package mapper
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// InterfaceFactoryFn receives the source value and returns a new value of a concrete type that implements
+// the target interface. The source value is then mapped into it, so returning a pointer (e.g. &CircleDTO{})
+// lets the mapping fill it in place.
+type InterfaceFactoryFn func(source interface{}) interface{}
+
+type interfaceKey struct {
+	target reflect.Type
+	source reflect.Type
+}
+
+// concreteResolverFn returns the concrete value to map the source into, and the source to map from
+type concreteResolverFn func(source reflect.Value) (concrete reflect.Value, from reflect.Value, err error)
+
+// RegisterInterfaceFactory registers the factory used to build values of the targetInterface type
+// when the dynamic type of the source value is sourceType (or a pointer to it)
+func RegisterInterfaceFactory(targetInterface, sourceType reflect.Type, factory InterfaceFactoryFn) {
+	defaultMapper.RegisterInterfaceFactory(targetInterface, sourceType, factory)
+}
+
+// RegisterInterfaceFactory registers the factory used to build values of the targetInterface type
+// when the dynamic type of the source value is sourceType (or a pointer to it)
+func (m *Mapper) RegisterInterfaceFactory(targetInterface, sourceType reflect.Type, factory InterfaceFactoryFn) {
+	m.registerInterfaceResolver(targetInterface, sourceType, func(source reflect.Value) (reflect.Value, reflect.Value, error) {
+		return reflect.ValueOf(factory(source.Interface())), source, nil
+	})
+}
+
+// RegisterConcreteType registers concreteType as the type built for targetInterface values
+// when the dynamic type of the source value is sourceType (or a pointer to it)
+func RegisterConcreteType(targetInterface, sourceType, concreteType reflect.Type) {
+	defaultMapper.RegisterConcreteType(targetInterface, sourceType, concreteType)
+}
+
+// RegisterConcreteType registers concreteType as the type built for targetInterface values
+// when the dynamic type of the source value is sourceType (or a pointer to it)
+func (m *Mapper) RegisterConcreteType(targetInterface, sourceType, concreteType reflect.Type) {
+	if !concreteType.Implements(targetInterface) {
+		panic(fmt.Sprintf("mapper: %v does not implement %v", concreteType, targetInterface))
+	}
+
+	m.registerInterfaceResolver(targetInterface, sourceType, func(source reflect.Value) (reflect.Value, reflect.Value, error) {
+		return newConcreteValue(concreteType), source, nil
+	})
+}
+
+// Discriminator describes how to pick the concrete type of an interface target
+// from the value of a field in the source struct (e.g. an event envelope with a `Type` field)
+type Discriminator struct {
+	// Field is the name of the source field holding the discriminator value
+	Field string
+	// PayloadField is the name of the source field mapped into the concrete type.
+	// If empty, the whole source struct is mapped.
+	PayloadField string
+	// Types maps each discriminator value to the concrete type built for it
+	Types map[string]reflect.Type
+}
+
+// RegisterDiscriminator registers the discriminator used to build values of the targetInterface type
+// when the dynamic type of the source value is sourceType (or a pointer to it)
+func RegisterDiscriminator(targetInterface, sourceType reflect.Type, discriminator Discriminator) {
+	defaultMapper.RegisterDiscriminator(targetInterface, sourceType, discriminator)
+}
+
+// RegisterDiscriminator registers the discriminator used to build values of the targetInterface type
+// when the dynamic type of the source value is sourceType (or a pointer to it)
+func (m *Mapper) RegisterDiscriminator(targetInterface, sourceType reflect.Type, discriminator Discriminator) {
+	for value, concreteType := range discriminator.Types {
+		if !concreteType.Implements(targetInterface) {
+			panic(fmt.Sprintf("mapper: %v registered for discriminator value %q does not implement %v", concreteType, value, targetInterface))
+		}
+	}
+
+	m.registerInterfaceResolver(targetInterface, sourceType, func(source reflect.Value) (reflect.Value, reflect.Value, error) {
+		source = reflect.Indirect(source)
+		fieldValue := m.fieldByName(source, discriminator.Field)
+		if !fieldValue.IsValid() {
+			return reflect.Value{}, reflect.Value{}, fmt.Errorf("discriminator field %v not found in %v", discriminator.Field, source.Type())
+		}
+
+		value := fmt.Sprintf("%v", fieldValue.Interface())
+		concreteType, ok := discriminator.Types[value]
+		if !ok {
+			return reflect.Value{}, reflect.Value{}, fmt.Errorf("%v %q for %v: %w", discriminator.Field, value, targetInterface, ErrUnknownDiscriminator)
+		}
+
+		from := source
+		if discriminator.PayloadField != "" {
+			from = m.fieldByName(source, discriminator.PayloadField)
+			if !from.IsValid() {
+				return reflect.Value{}, reflect.Value{}, fmt.Errorf("discriminator payload field %v not found in %v", discriminator.PayloadField, source.Type())
+			}
+		}
+
+		return newConcreteValue(concreteType), from, nil
+	})
+}
+
+func (m *Mapper) registerInterfaceResolver(targetInterface, sourceType reflect.Type, resolver concreteResolverFn) {
+	if targetInterface.Kind() != reflect.Interface {
+		panic(fmt.Sprintf("mapper: %v is not an interface type", targetInterface))
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.interfaceResolvers[interfaceKey{targetInterface, indirectType(sourceType)}] = resolver
+}
+
+func (m *Mapper) lookupInterfaceResolver(targetInterface, sourceType reflect.Type) (concreteResolverFn, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	resolver, ok := m.interfaceResolvers[interfaceKey{targetInterface, indirectType(sourceType)}]
+	return resolver, ok
+}
+
+// newConcreteValue returns a new zero value of the given type, allocating it when it's a pointer type
+func newConcreteValue(concreteType reflect.Type) reflect.Value {
+	if concreteType.Kind() == reflect.Ptr {
+		return reflect.New(concreteType.Elem())
+	}
+	return reflect.New(concreteType).Elem()
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		return t.Elem()
+	}
+	return t
+}
+
+func mapToInterface(sourceValue, targetValue reflect.Value, state *mappingState) (interface{}, error) {
+	// Nil sources leave the target nil, there's nothing to resolve a concrete type from
+	if !sourceValue.IsValid() || (sourceValue.Kind() == reflect.Ptr && sourceValue.IsNil()) {
+		return nil, nil
+	}
+
+	resolver, ok := state.mapper.lookupInterfaceResolver(targetValue.Type(), sourceValue.Type())
+	if !ok {
+		// Without a registered concrete type the source value can only be assigned as is
+		if !sourceValue.Type().AssignableTo(targetValue.Type()) {
+			return nil, fmt.Errorf("cannot map %v into %v: %w", sourceValue.Type(), targetValue.Type(), ErrNoConcreteType)
+		}
+		if targetValue.CanSet() {
+			targetValue.Set(sourceValue)
+		}
+		return sourceValue.Interface(), nil
+	}
+
+	concrete, from, err := resolver(sourceValue)
+	if err != nil {
+		return nil, err
+	}
+	if !concrete.IsValid() {
+		return nil, fmt.Errorf("factory for %v returned nil for %v: %w", targetValue.Type(), sourceValue.Type(), ErrUnexpectedNil)
+	}
+
+	// Map into an addressable value of the concrete type
+	result := concrete
+	if concrete.Kind() == reflect.Ptr && !concrete.IsNil() {
+		if _, err := mapValues(from, concrete.Elem(), state); err != nil {
+			return nil, err
+		}
+	} else {
+		result = reflect.New(concrete.Type()).Elem()
+		result.Set(concrete)
+		if _, err := mapValues(from, result, state); err != nil {
+			return nil, err
+		}
+	}
+
+	if !result.Type().AssignableTo(targetValue.Type()) {
+		return nil, fmt.Errorf("%v does not implement %v", result.Type(), targetValue.Type())
+	}
+	if targetValue.CanSet() {
+		targetValue.Set(result)
+	}
+
+	return result.Interface(), nil
+}
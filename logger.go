@@ -0,0 +1,146 @@
+package mapper
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"reflect"
+	"strings"
+)
+
+// Logger receives the diagnostics of a Mapper, e.g. skipped fields, invalid values and converter fallbacks.
+// Mappers are silent by default, see WithLogger.
+type Logger interface {
+	// Enabled reports whether events of the given level are logged, so they are only built when needed
+	Enabled(ctx context.Context, level slog.Level) bool
+	Log(ctx context.Context, event LogEvent)
+}
+
+// LogEvent is a diagnostic of a mapping
+type LogEvent struct {
+	Level   slog.Level
+	Message string
+	// Path is the path of the target value, e.g. "Children[0].Name"
+	Path       string
+	SourceType string
+	TargetType string
+	Reason     string
+}
+
+// attrs returns the fields of the event as slog attributes, leaving out the empty ones
+func (e LogEvent) attrs() []slog.Attr {
+	var attrs []slog.Attr
+	for _, attr := range []slog.Attr{
+		slog.String("path", e.Path),
+		slog.String("source_type", e.SourceType),
+		slog.String("target_type", e.TargetType),
+		slog.String("reason", e.Reason),
+	} {
+		if attr.Value.String() != "" {
+			attrs = append(attrs, attr)
+		}
+	}
+	return attrs
+}
+
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+// SlogLogger returns a Logger writing structured events to the given slog.Logger, e.g:
+//
+//	mapper.New(mapper.WithLogger(mapper.SlogLogger(slog.Default())))
+func SlogLogger(logger *slog.Logger) Logger {
+	return slogLogger{logger}
+}
+
+func (l slogLogger) Enabled(ctx context.Context, level slog.Level) bool {
+	return l.logger.Enabled(ctx, level)
+}
+
+func (l slogLogger) Log(ctx context.Context, event LogEvent) {
+	l.logger.LogAttrs(ctx, event.Level, event.Message, event.attrs()...)
+}
+
+type stdLogger struct {
+	logger *log.Logger
+	level  slog.Level
+}
+
+// StdLogger returns a Logger writing the events of the given level or above to a log.Logger, e.g:
+//
+//	mapper.New(mapper.WithLogger(mapper.StdLogger(log.Default(), slog.LevelWarn)))
+func StdLogger(logger *log.Logger, level slog.Level) Logger {
+	return stdLogger{logger, level}
+}
+
+func (l stdLogger) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= l.level
+}
+
+func (l stdLogger) Log(_ context.Context, event LogEvent) {
+	var b strings.Builder
+	b.WriteString(event.Level.String())
+	b.WriteByte(' ')
+	b.WriteString(event.Message)
+	for _, attr := range event.attrs() {
+		b.WriteByte(' ')
+		b.WriteString(attr.String())
+	}
+	l.logger.Println(b.String())
+}
+
+// logEnabled reports whether the Mapper logs events of the given level
+func (s *mappingState) logEnabled(level slog.Level) bool {
+	return s.mapper.logger != nil && s.mapper.logger.Enabled(s.ctx, level)
+}
+
+// log sends an event for the current path to the logger of the Mapper, if it's enabled for the level
+func (s *mappingState) log(level slog.Level, message, reason string, sourceType, targetType reflect.Type) {
+	if !s.logEnabled(level) {
+		return
+	}
+	s.mapper.logger.Log(s.ctx, LogEvent{
+		Level:      level,
+		Message:    message,
+		Path:       s.currentPath(),
+		SourceType: typeName(sourceType),
+		TargetType: typeName(targetType),
+		Reason:     reason,
+	})
+}
+
+// skipField records (and logs) why the target field of the current path is skipped
+func (s *mappingState) skipField(sourceValue reflect.Value, targetType reflect.Type, r resolution) {
+	s.trace(r)
+	s.logSkipped(sourceValue, targetType, r.kind)
+}
+
+// skipNamedField records (and logs) why the named field of the current path is skipped
+func (s *mappingState) skipNamedField(name string, sourceValue reflect.Value, targetType reflect.Type, r resolution) {
+	if s.plan == nil && !s.logEnabled(slog.LevelDebug) {
+		return
+	}
+	s.push(name)
+	s.skipField(sourceValue, targetType, r)
+	s.pop()
+}
+
+// logSkipped logs why the target field of the current path is skipped, e.g. "missing" for SkippedMissing
+func (s *mappingState) logSkipped(sourceValue reflect.Value, targetType reflect.Type, kind Resolution) {
+	if !s.logEnabled(slog.LevelDebug) {
+		return
+	}
+	var sourceType reflect.Type
+	if sourceValue.IsValid() {
+		sourceType = sourceValue.Type()
+	}
+	s.log(slog.LevelDebug, "skipped field", strings.TrimPrefix(string(kind), "skipped: "), sourceType, targetType)
+}
+
+func typeName(t reflect.Type) string {
+	if t == nil {
+		return ""
+	}
+	return t.String()
+}
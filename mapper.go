@@ -0,0 +1,223 @@
+package mapper
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Mapper copies values from source to target objects. It owns its configuration (converters, registered interface
+// types, enums and transforms, policies and logger) and caches, so different Mappers are isolated from each other.
+// A Mapper is safe for concurrent use.
+type Mapper struct {
+	converters map[string]TypeConverterFn
+	config     *Config
+	logger     Logger
+	naming     []NamingStrategy
+	sourceTag  string
+	targetTag  string
+	strict     bool
+	merge      bool
+
+	// contextConverters are kept apart from converters, since they receive the context of the mapping
+	contextConverters     map[string]ContextConverterFn
+	instrumentation       Instrumentation
+	nestedInstrumentation bool
+	parallel              *ParallelPolicy
+	getters               bool
+
+	// mu guards the registries below
+	mu                 sync.RWMutex
+	interfaceResolvers map[interfaceKey]concreteResolverFn
+	enumTables         map[enumKey]enumTable
+	transforms         map[string]TransformFn
+
+	fieldCache  sync.Map // map[reflect.Type]*structFields
+	setterCache sync.Map // map[reflect.Type][]setterField
+	namingCache sync.Map // map[namingKey]namingMatch
+	matchCache  sync.Map // map[reflect.Type]*structFields
+	renameCache sync.Map // map[reflect.Type]map[string]namingMatch
+}
+
+// Option configures a Mapper
+type Option func(*Mapper)
+
+// WithConverters adds converters for custom types, which are used in every mapping of the Mapper.
+// Converters are registered by the target type name (e.g. "time.Time"), and replace the default ones.
+func WithConverters(converters map[string]TypeConverterFn) Option {
+	return func(m *Mapper) {
+		for k, v := range converters {
+			m.converters[k] = v
+			delete(m.contextConverters, k)
+		}
+	}
+}
+
+// WithTimePolicy sets how time.Time values are copied (see TimePolicy)
+func WithTimePolicy(policy TimePolicy) Option {
+	return WithConverters(map[string]TypeConverterFn{"time.Time": TimeConverter(policy)})
+}
+
+// WithConfig sets the programmatic configuration of type pairs used by the Mapper
+func WithConfig(cfg *Config) Option {
+	return func(m *Mapper) {
+		m.config = cfg
+	}
+}
+
+// WithLogger sets the logger receiving the diagnostics of the Mapper (see SlogLogger and StdLogger).
+// Mappers are silent by default.
+func WithLogger(logger Logger) Option {
+	return func(m *Mapper) {
+		m.logger = logger
+	}
+}
+
+// WithNaming sets the naming strategies used to find the source field of a target field when the source has no field
+// with the exact same name. Strategies are tried in order, and the first one matching a field is used, e.g:
+//
+//	mapper.New(mapper.WithNaming(mapper.CaseInsensitive(), mapper.StripPrefix("User")))
+func WithNaming(strategies ...NamingStrategy) Option {
+	return func(m *Mapper) {
+		m.naming = append(m.naming, strategies...)
+	}
+}
+
+// WithMatchTag matches source and target fields by the value of the struct tag with the given key
+// (e.g. "json", "db" or "yaml") instead of their Go names. See WithMatchTags.
+func WithMatchTag(key string) Option {
+	return WithMatchTags(key, key)
+}
+
+// WithMatchTags matches source and target fields by the value of their struct tags instead of their Go names,
+// e.g. `gorm:"column:user_name"` in the source and `json:"user_name"` in the target. Fields without the tag
+// (or without a name, like `json:",omitempty"`) use their Go name, and fields tagged with `-` are ignored.
+func WithMatchTags(sourceKey, targetKey string) Option {
+	return func(m *Mapper) {
+		m.sourceTag = sourceKey
+		m.targetTag = targetKey
+	}
+}
+
+// WithGetterFallback enables the getter convention: when the source has no field named like a target field
+// (e.g. `Name`), a zero-argument `GetName` or `Name` method of the source is used instead, if any.
+// This is useful for protobuf-style and encapsulated types. It's disabled by default.
+func WithGetterFallback() Option {
+	return func(m *Mapper) {
+		m.getters = true
+	}
+}
+
+// WithStrict makes the mapping fail with an ErrMissingSourceField error when an exported target field
+// can't be resolved from the source (unless it's ignored in the configuration)
+func WithStrict() Option {
+	return func(m *Mapper) {
+		m.strict = true
+	}
+}
+
+// WithMerge skips source fields with zero values, so that the existing target values are kept.
+// This is useful to apply partial updates (e.g. a PATCH request) onto an existing target.
+func WithMerge() Option {
+	return func(m *Mapper) {
+		m.merge = true
+	}
+}
+
+// New returns a Mapper configured with the given options
+func New(opts ...Option) *Mapper {
+	m := &Mapper{
+		converters:         make(map[string]TypeConverterFn, len(defaultTypeConvertMap)),
+		contextConverters:  make(map[string]ContextConverterFn),
+		config:             NewConfig(),
+		interfaceResolvers: make(map[interfaceKey]concreteResolverFn),
+		enumTables:         make(map[enumKey]enumTable),
+		transforms:         make(map[string]TransformFn, len(builtinTransforms)),
+	}
+	for k, v := range defaultTypeConvertMap {
+		m.converters[k] = v
+	}
+	for k, v := range builtinTransforms {
+		m.transforms[k] = v
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// defaultMapper is used by the package-level functions
+var defaultMapper = New()
+
+// Config returns the programmatic configuration of type pairs used by the Mapper, e.g:
+//
+//	mapper.For[User, UserDTO](m.Config()).Ignore("Password")
+func (m *Mapper) Config() *Config {
+	return m.config
+}
+
+// Map copies values from source to target (pointer), and returns an error if any
+func (m *Mapper) Map(source, target interface{}) error {
+	return m.mapWithContext(context.Background(), source, target, nil)
+}
+
+// MapContext copies values from source to target (pointer), and returns an error if any.
+// The context is passed to `fromMethod` methods receiving a context.Context argument and to context converters
+// (see WithContextConverters). Once it's done, the mapping stops before the next slice element, map entry or
+// struct field, and returns the context error wrapped in a FieldError with the path reached.
+func (m *Mapper) MapContext(ctx context.Context, source, target interface{}) error {
+	return m.mapWithContext(ctx, source, target, nil)
+}
+
+// MapWithConverters copies values from source to target (pointer), returns an error if any,
+// and uses the `converters` map (on top of the Mapper ones) to convert custom types
+func (m *Mapper) MapWithConverters(source, target interface{}, converters map[string]TypeConverterFn) error {
+	return m.mapWithContext(context.Background(), source, target, converters)
+}
+
+func (m *Mapper) mapWithContext(ctx context.Context, source, target interface{}, converters map[string]TypeConverterFn) error {
+	if ctx == nil {
+		return fmt.Errorf("invalid context parameter: %w", ErrUnexpectedNil)
+	}
+
+	// merge maps
+	converterFnMap := m.converters
+	if len(converters) > 0 {
+		converterFnMap = make(map[string]TypeConverterFn, len(m.converters)+len(converters))
+		for k, v := range m.converters {
+			converterFnMap[k] = v
+		}
+		for k, v := range converters {
+			converterFnMap[k] = v
+		}
+	}
+
+	return m.mapWithState(source, target, &mappingState{ctx: ctx, converters: converterFnMap})
+}
+
+// mapWithState maps source into target with the given state, filling in the Mapper settings
+func (m *Mapper) mapWithState(source, target interface{}, state *mappingState) error {
+	if err := validateParameters(source, target); err != nil {
+		return err
+	}
+
+	state.mapper = m
+	if state.converters == nil {
+		state.converters = m.converters
+	}
+	state.getters = m.getters
+
+	targetValue := reflect.Indirect(reflect.ValueOf(target))
+	if m.instrumentation == nil {
+		_, err := mapValues(reflect.ValueOf(source), targetValue, state)
+		return err
+	}
+
+	o := state.startObservation()
+	_, err := mapValues(reflect.ValueOf(source), targetValue, state)
+	state.observe(o, TypePair{indirectType(reflect.TypeOf(source)), targetValue.Type()}, err)
+	return err
+}
@@ -0,0 +1,14 @@
+// Command mappervet reports mistakes in the usage of the github.com/agustinaliagac/mapper package, e.g:
+//
+//	go install github.com/agustinaliagac/mapper/mappervet/cmd/mappervet@latest
+//	mappervet ./...
+package main
+
+import (
+	"github.com/agustinaliagac/mapper/mappervet"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(mappervet.Analyzer)
+}
@@ -0,0 +1,291 @@
+// Package mappervet defines an Analyzer that reports mistakes in the usage of the mapper package
+// that would otherwise only surface at run-time:
+//   - Map, MapContext and MapWithConverters calls whose target is not a pointer (ErrMustBePointer)
+//   - `mapper` struct tags whose fromField or fromMethod don't exist in the source types the struct is mapped from
+//   - fields whose source and target types are statically incompatible
+//
+// The source types of a target struct are taken from the Map calls, and the For and Pair instantiations
+// found in the package.
+package mappervet
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"github.com/fatih/structtag"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const mapperPath = "github.com/agustinaliagac/mapper"
+
+// Analyzer reports mistakes in the usage of the mapper package
+var Analyzer = &analysis.Analyzer{
+	Name:     "mappervet",
+	Doc:      "check for mistakes in the usage of the github.com/agustinaliagac/mapper package",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// targetArgs holds the index of the target argument of the mapping functions and methods
+var targetArgs = map[string]int{
+	"Map":               1,
+	"MapContext":        2,
+	"MapWithConverters": 1,
+}
+
+type typePair struct {
+	source types.Type
+	target types.Type
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	// Each type pair is checked once
+	var checked []typePair
+	checkPair := func(call ast.Node, source, target types.Type) {
+		for _, pair := range checked {
+			if types.Identical(pair.source, source) && types.Identical(pair.target, target) {
+				return
+			}
+		}
+		checked = append(checked, typePair{source, target})
+		checkTags(pass, call, source, target)
+	}
+
+	inspect.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		fn := calledFunc(pass.TypesInfo, call.Fun)
+		if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != mapperPath {
+			return
+		}
+
+		// For[Src, Dst](cfg) and Pair[Src, Dst]() declare a source and a target type
+		if fn.Name() == "For" || fn.Name() == "Pair" {
+			if typeArgs := instanceTypeArgs(pass.TypesInfo, call.Fun); typeArgs != nil && typeArgs.Len() == 2 {
+				checkPair(call, typeArgs.At(0), typeArgs.At(1))
+			}
+			return
+		}
+
+		index, ok := targetArgs[fn.Name()]
+		if !ok || len(call.Args) <= index {
+			return
+		}
+
+		target := call.Args[index]
+		targetType := pass.TypesInfo.TypeOf(target)
+		if targetType == nil || isNil(pass.TypesInfo, target) {
+			return
+		}
+		// Interfaces may hold a pointer, it's only known at run-time
+		if _, ok := targetType.Underlying().(*types.Interface); ok {
+			return
+		}
+
+		ptr, ok := targetType.Underlying().(*types.Pointer)
+		if !ok {
+			pass.Reportf(target.Pos(), "mapper.%v target must be a pointer, got %v", fn.Name(), targetType)
+			return
+		}
+
+		sourceType := pass.TypesInfo.TypeOf(call.Args[index-1])
+		if sourceType != nil && !isNil(pass.TypesInfo, call.Args[index-1]) {
+			checkPair(call, sourceType, ptr.Elem())
+		}
+	})
+
+	return nil, nil
+}
+
+// calledFunc returns the function or method called, if it's statically known
+func calledFunc(info *types.Info, fun ast.Expr) *types.Func {
+	switch f := astutil.Unparen(fun).(type) {
+	case *ast.IndexExpr:
+		return calledFunc(info, f.X)
+	case *ast.IndexListExpr:
+		return calledFunc(info, f.X)
+	case *ast.Ident:
+		fn, _ := info.Uses[f].(*types.Func)
+		return fn
+	case *ast.SelectorExpr:
+		fn, _ := info.Uses[f.Sel].(*types.Func)
+		return fn
+	}
+	return nil
+}
+
+// instanceTypeArgs returns the type arguments of an instantiated generic function
+func instanceTypeArgs(info *types.Info, fun ast.Expr) *types.TypeList {
+	switch f := astutil.Unparen(fun).(type) {
+	case *ast.IndexExpr:
+		return instanceTypeArgs(info, f.X)
+	case *ast.IndexListExpr:
+		return instanceTypeArgs(info, f.X)
+	case *ast.Ident:
+		return info.Instances[f].TypeArgs
+	case *ast.SelectorExpr:
+		return info.Instances[f.Sel].TypeArgs
+	}
+	return nil
+}
+
+func isNil(info *types.Info, expr ast.Expr) bool {
+	tv, ok := info.Types[expr]
+	return ok && tv.IsNil()
+}
+
+// checkTags checks the `mapper` tags and the field types of the target struct against the source type
+func checkTags(pass *analysis.Pass, call ast.Node, source, target types.Type) {
+	source = indirect(source)
+	targetStruct, ok := indirect(target).Underlying().(*types.Struct)
+	if !ok {
+		return
+	}
+	if _, ok := source.Underlying().(*types.Struct); !ok {
+		return
+	}
+
+	for i := 0; i < targetStruct.NumFields(); i++ {
+		f := targetStruct.Field(i)
+		if !f.Exported() || f.Embedded() {
+			continue
+		}
+
+		// Report at the field when it's declared in the package being analyzed, otherwise at the call
+		pos := call.Pos()
+		if f.Pkg() == pass.Pkg {
+			pos = f.Pos()
+		}
+
+		settings := tagSettings(targetStruct.Tag(i))
+		sourceType, ok := sourceFieldType(pass, pos, source, f, settings)
+		if !ok || converted(settings) {
+			continue
+		}
+		if incompatible(sourceType, f.Type()) {
+			pass.Reportf(pos, "field %v of %v cannot be mapped from %v of %v", f.Name(), indirect(target), sourceType, source)
+		}
+	}
+}
+
+// converted reports whether the source value is converted (or passed to a setter) before being mapped,
+// so its type is not checked
+func converted(settings map[string]string) bool {
+	for _, option := range []string{"layout", "unix", "unixMilli", "transform", "toMethod"} {
+		if _, ok := settings[option]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// sourceFieldType returns the type of the source value of the target field, reporting the missing fromField
+// and fromMethod targets
+func sourceFieldType(pass *analysis.Pass, pos token.Pos, source types.Type, f *types.Var, settings map[string]string) (types.Type, bool) {
+	if path, ok := settings["fromField"]; ok {
+		t, found := fieldPathType(source, path)
+		if !found {
+			pass.Reportf(pos, "field %v: fromField %v not found in %v", f.Name(), path, source)
+		}
+		return t, found
+	}
+
+	if call, ok := settings["fromMethod"]; ok {
+		name := call
+		if open := strings.IndexByte(call, '('); open >= 0 {
+			name = call[:open]
+		}
+		obj, _, _ := types.LookupFieldOrMethod(source, true, nil, name)
+		method, isMethod := obj.(*types.Func)
+		if !isMethod || !method.Exported() {
+			pass.Reportf(pos, "field %v: fromMethod %v not found in %v", f.Name(), name, source)
+			return nil, false
+		}
+		results := method.Type().(*types.Signature).Results()
+		if results.Len() == 0 {
+			return nil, false
+		}
+		return results.At(0).Type(), true
+	}
+
+	return fieldPathType(source, f.Name())
+}
+
+// fieldPathType returns the type of the exported source field with the given dotted path, if any
+func fieldPathType(t types.Type, path string) (types.Type, bool) {
+	for _, name := range strings.Split(path, ".") {
+		obj, _, _ := types.LookupFieldOrMethod(indirect(t), true, nil, name)
+		field, ok := obj.(*types.Var)
+		if !ok || !field.IsField() || !field.Exported() {
+			return nil, false
+		}
+		t = field.Type()
+	}
+	return t, true
+}
+
+// incompatible reports the types that can never be mapped. It's conservative, since converters, enums and
+// interfaces like driver.Valuer or encoding.TextUnmarshaler are only known at run-time:
+// only predeclared types, slices and maps are compared.
+func incompatible(source, target types.Type) bool {
+	source = indirect(source)
+	target = indirect(target)
+
+	targetBasic, ok := target.(*types.Basic)
+	if !ok {
+		// Slices can only be mapped from slices
+		if _, isSlice := target.(*types.Slice); isSlice {
+			_, fromBasic := source.(*types.Basic)
+			return fromBasic
+		}
+		return false
+	}
+	// Any value can be formatted into a string
+	if targetBasic.Info()&types.IsString != 0 {
+		return false
+	}
+
+	switch s := source.(type) {
+	case *types.Basic:
+		return !types.ConvertibleTo(s, target)
+	case *types.Slice, *types.Map:
+		return true
+	}
+	return false
+}
+
+func indirect(t types.Type) types.Type {
+	if ptr, ok := t.(*types.Pointer); ok {
+		return ptr.Elem()
+	}
+	return t
+}
+
+// tagSettings parses the settings of the `mapper` struct tag, like the mapper package does
+func tagSettings(tag string) map[string]string {
+	settings := map[string]string{}
+	tags, _ := structtag.Parse(tag)
+	mapperTag, _ := tags.Get("mapper")
+	if mapperTag == nil {
+		return settings
+	}
+
+	for _, setting := range strings.Split(mapperTag.Value(), ";") {
+		if setting == "" {
+			continue
+		}
+		// Values may contain colons themselves (e.g. a time layout), so only split on the first one
+		parts := strings.SplitN(setting, ":", 2)
+		settings[parts[0]] = ""
+		if len(parts) > 1 {
+			settings[parts[0]] = parts[1]
+		}
+	}
+	return settings
+}
@@ -0,0 +1,68 @@
+package a
+
+import (
+	"context"
+	"time"
+
+	"github.com/agustinaliagac/mapper"
+)
+
+type Address struct {
+	City string
+}
+
+type User struct {
+	ID        int
+	FirstName string
+	LastName  string
+	Email     string
+	Tags      []string
+	Created   time.Time
+	Address   *Address
+}
+
+func (u User) GetFullName() string {
+	return u.FirstName + " " + u.LastName
+}
+
+func (u *User) Initials(short bool) string {
+	return u.FirstName[:1]
+}
+
+type UserDTO struct {
+	ID       string
+	Name     string `mapper:"fromField:FirstName"`
+	FullName string `mapper:"fromMethod:GetFullName"`
+	Initials string `mapper:"fromMethod:Initials(true)"`
+	City     string `mapper:"fromField:Address.City"`
+	Created  string `mapper:"layout:2006-01-02"`
+	Tags     []string
+}
+
+type BrokenDTO struct {
+	Name     string   `mapper:"fromField:FristName"`    // want `field Name: fromField FristName not found in a.User`
+	FullName string   `mapper:"fromMethod:GetFullNmae"` // want `field FullName: fromMethod GetFullNmae not found in a.User`
+	City     string   `mapper:"fromField:Address.Town"` // want `field City: fromField Address.Town not found in a.User`
+	Email    int      // want `field Email of a.BrokenDTO cannot be mapped from string of a.User`
+	Tags     int      // want `field Tags of a.BrokenDTO cannot be mapped from \[\]string of a.User`
+	LastName []string `mapper:"fromField:LastName"` // want `field LastName of a.BrokenDTO cannot be mapped from string of a.User`
+	Created  int64    `mapper:"unix"`
+}
+
+func mapUsers(ctx context.Context, user User, users []User) {
+	var dto UserDTO
+	_ = mapper.Map(user, &dto)
+	_ = mapper.Map(&user, dto)                   // want `mapper.Map target must be a pointer, got a.UserDTO`
+	_ = mapper.MapContext(ctx, user, dto)        // want `mapper.MapContext target must be a pointer, got a.UserDTO`
+	_ = mapper.MapWithConverters(user, dto, nil) // want `mapper.MapWithConverters target must be a pointer, got a.UserDTO`
+	_ = mapper.New().Map(user, dto)              // want `mapper.Map target must be a pointer, got a.UserDTO`
+
+	var dtos []UserDTO
+	_ = mapper.Map(users, &dtos)
+	_ = mapper.Map(users, dtos) // want `mapper.Map target must be a pointer, got \[\]a.UserDTO`
+
+	var target interface{} = &dto
+	_ = mapper.Map(user, target)
+
+	_ = mapper.Pair[User, BrokenDTO]()
+}
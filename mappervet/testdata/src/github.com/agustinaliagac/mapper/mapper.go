@@ -0,0 +1,32 @@
+// Package mapper is a stub of github.com/agustinaliagac/mapper for the analyzer tests
+package mapper
+
+import "context"
+
+type TypeConverterFn func(interface{}) interface{}
+
+type Mapper struct{}
+
+type Config struct{}
+
+type TypeMap struct{}
+
+type TypePair struct{}
+
+func New() *Mapper { return &Mapper{} }
+
+func Map(source, target interface{}) error { return nil }
+
+func MapContext(ctx context.Context, source, target interface{}) error { return nil }
+
+func MapWithConverters(source, target interface{}, converters map[string]TypeConverterFn) error {
+	return nil
+}
+
+func (m *Mapper) Map(source, target interface{}) error { return nil }
+
+func (m *Mapper) MapContext(ctx context.Context, source, target interface{}) error { return nil }
+
+func For[Src, Dst any](cfg *Config) *TypeMap { return nil }
+
+func Pair[Src, Dst any]() TypePair { return TypePair{} }
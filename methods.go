@@ -0,0 +1,140 @@
+package mapper
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// lookupMethod finds the method by name on the value, or on a pointer to a copy of it
+func lookupMethod(value reflect.Value, name string) reflect.Value {
+	if !value.IsValid() {
+		return reflect.Value{}
+	}
+
+	// Search struct receiver. E.g: func (s PersonTest) GetFullName() string
+	method := value.MethodByName(name)
+	if !method.IsValid() && value.Kind() != reflect.Ptr {
+		// Search pointer receiver. E.g: func (s *PersonTest) GetFullName() string
+		ptr := reflect.New(value.Type())
+		ptr.Elem().Set(value)
+		method = ptr.MethodByName(name)
+	}
+
+	return method
+}
+
+// callSourceMethod calls a `fromMethod` method of the source value and returns its first result. The call may be
+// a method name or include literal arguments, e.g. `FormatName(short)`. A context.Context argument is filled with
+// the mapping context, and a trailing error result is returned as an error.
+// It returns an invalid Value when the method does not exist.
+func callSourceMethod(ctx context.Context, sourceValue reflect.Value, call string) (reflect.Value, error) {
+	name, literals, err := parseTransformCall(call)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	method := lookupMethod(sourceValue, name)
+	if !method.IsValid() {
+		return reflect.Value{}, nil
+	}
+
+	methodType := method.Type()
+	if methodType.IsVariadic() {
+		return reflect.Value{}, fmt.Errorf("method %v: variadic methods are not supported", name)
+	}
+
+	args := make([]reflect.Value, methodType.NumIn())
+	for i := range args {
+		in := methodType.In(i)
+		if in == contextType {
+			args[i] = reflect.ValueOf(&ctx).Elem()
+			continue
+		}
+
+		if len(literals) == 0 {
+			return reflect.Value{}, fmt.Errorf("method %v: missing argument of type %v", name, in)
+		}
+		if args[i], err = parseLiteral(literals[0], in); err != nil {
+			return reflect.Value{}, fmt.Errorf("method %v: %w", name, err)
+		}
+		literals = literals[1:]
+	}
+	if len(literals) > 0 {
+		return reflect.Value{}, fmt.Errorf("method %v: too many arguments", name)
+	}
+
+	values := method.Call(args)
+	if n := len(values); n > 1 && methodType.Out(n-1) == errorType && !values[n-1].IsNil() {
+		return reflect.Value{}, values[n-1].Interface().(error)
+	}
+	if len(values) == 0 {
+		return reflect.Value{}, nil
+	}
+
+	return values[0], nil
+}
+
+// callGetter calls the `Get<name>` or `<name>` getter of the source value, which must receive
+// no arguments (other than a context.Context) and return at least one value.
+// It returns an invalid Value if there's no such getter.
+func callGetter(ctx context.Context, sourceValue reflect.Value, name string) (reflect.Value, error) {
+	for _, getter := range []string{"Get" + name, name} {
+		method := lookupMethod(sourceValue, getter)
+		if !method.IsValid() {
+			continue
+		}
+
+		methodType := method.Type()
+		if methodType.NumOut() == 0 || methodType.NumIn() > 1 || (methodType.NumIn() == 1 && methodType.In(0) != contextType) {
+			continue
+		}
+
+		return callSourceMethod(ctx, sourceValue, getter)
+	}
+
+	return reflect.Value{}, nil
+}
+
+// parseLiteral converts a literal argument from the tag into a value of the given type
+func parseLiteral(literal string, t reflect.Type) (reflect.Value, error) {
+	v := reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.String:
+		v.SetString(literal)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(literal)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(literal, 10, t.Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(literal, 10, t.Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(literal, t.Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		v.SetFloat(f)
+	default:
+		return reflect.Value{}, fmt.Errorf("unsupported argument type %v", t)
+	}
+
+	return v, nil
+}
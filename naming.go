@@ -0,0 +1,148 @@
+package mapper
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// NamingStrategy returns the names of the source fields matching a target field name. It's only used when the
+// source has no field with the exact same name. More than one match is reported as an ErrAmbiguousField error.
+type NamingStrategy func(targetField string, sourceFields []string) []string
+
+// CaseInsensitive matches fields whose names only differ in case, e.g. `UserID` and `UserId`
+func CaseInsensitive() NamingStrategy {
+	return matchByKey(strings.ToLower)
+}
+
+// SnakeCase matches snake_case and CamelCase names, e.g. `Created_At` and `CreatedAt`
+func SnakeCase() NamingStrategy {
+	return matchByKey(snakeCase)
+}
+
+// StripPrefix matches fields whose names are equal once the prefixes are removed,
+// e.g. `UserName` and `Name` with the `User` prefix
+func StripPrefix(prefixes ...string) NamingStrategy {
+	return matchByKey(func(name string) string {
+		for _, prefix := range prefixes {
+			if len(name) > len(prefix) && strings.HasPrefix(name, prefix) {
+				return name[len(prefix):]
+			}
+		}
+		return name
+	})
+}
+
+// StripSuffix matches fields whose names are equal once the suffixes are removed,
+// e.g. `NameField` and `Name` with the `Field` suffix
+func StripSuffix(suffixes ...string) NamingStrategy {
+	return matchByKey(func(name string) string {
+		for _, suffix := range suffixes {
+			if len(name) > len(suffix) && strings.HasSuffix(name, suffix) {
+				return name[:len(name)-len(suffix)]
+			}
+		}
+		return name
+	})
+}
+
+// Candidates matches the source fields named like any of the candidates returned by fn for the target field
+func Candidates(fn func(targetField string) []string) NamingStrategy {
+	return func(targetField string, sourceFields []string) []string {
+		var matches []string
+		for _, candidate := range fn(targetField) {
+			for _, name := range sourceFields {
+				if name == candidate && !containsString(matches, name) {
+					matches = append(matches, name)
+				}
+			}
+		}
+		return matches
+	}
+}
+
+// matchByKey matches the source fields whose key is equal to the key of the target field
+func matchByKey(key func(string) string) NamingStrategy {
+	return func(targetField string, sourceFields []string) []string {
+		var matches []string
+		targetKey := key(targetField)
+		for _, name := range sourceFields {
+			if key(name) == targetKey {
+				matches = append(matches, name)
+			}
+		}
+		return matches
+	}
+}
+
+// snakeCase converts a CamelCase name into snake_case, keeping acronyms together (e.g. `UserID` is `user_id`)
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 && runes[i-1] != '_' {
+			prevLower := unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || (nextLower && unicode.IsUpper(runes[i-1])) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+type namingKey struct {
+	source reflect.Type
+	target string
+}
+
+// namingMatch is the cached result of the naming strategies for a source type and target field name
+type namingMatch struct {
+	name string
+	err  error
+}
+
+// resolveFieldName returns the name of the source field matching the target field name using the naming strategies
+// of the Mapper. It returns an empty name if there's no match.
+func (m *Mapper) resolveFieldName(sourceType reflect.Type, targetField string) (string, error) {
+	if len(m.naming) == 0 {
+		return "", nil
+	}
+
+	key := namingKey{sourceType, targetField}
+	if match, ok := m.namingCache.Load(key); ok {
+		return match.(namingMatch).name, match.(namingMatch).err
+	}
+
+	var match namingMatch
+	fields := m.matchFields(sourceType).list
+	names := make([]string, 0, len(fields))
+	for _, f := range fields {
+		names = append(names, f.name)
+	}
+	for _, strategy := range m.naming {
+		matches := strategy(targetField, names)
+		if len(matches) == 1 {
+			match.name = matches[0]
+			break
+		}
+		if len(matches) > 1 {
+			match.err = fmt.Errorf("%v matches %v in %v: %w", targetField, strings.Join(matches, ", "), sourceType, ErrAmbiguousField)
+			break
+		}
+	}
+
+	m.namingCache.Store(key, match)
+	return match.name, match.err
+}
@@ -1,24 +1,66 @@
 package mapper
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"reflect"
 	"strings"
-	"time"
-
-	"github.com/fatih/structtag"
 )
 
 // TypeConverterFn is a function that receives any value and converts it into a different type that is returned
 type TypeConverterFn func(interface{}) interface{}
 
+// mappingState holds the state shared by all the nested mappings of a single Map call
+type mappingState struct {
+	ctx        context.Context
+	mapper     *Mapper
+	converters map[string]TypeConverterFn
+	// getters enables the `Get<Field>` / `<Field>` methods fallback for missing source fields
+	getters bool
+	// path holds the segments leading to the value being mapped, e.g. ["Children", "[0]", "Name"]
+	path []string
+	// plan records how each target field is resolved, only when tracing (see MapWithTrace)
+	plan *Plan
+	// elements counts the slice elements and map entries mapped, for the Instrumentation of the Mapper
+	elements int
+	// forked is set for the states of the goroutines mapping slice elements in parallel
+	forked bool
+}
+
+func (s *mappingState) push(segment string) {
+	s.path = append(s.path, segment)
+}
+
+func (s *mappingState) pop() {
+	s.path = s.path[:len(s.path)-1]
+}
+
+// currentPath returns the path of the value being mapped, e.g. "Children[0].Name"
+func (s *mappingState) currentPath() string {
+	var b strings.Builder
+	for i, segment := range s.path {
+		if i > 0 && !strings.HasPrefix(segment, "[") {
+			b.WriteByte('.')
+		}
+		b.WriteString(segment)
+	}
+	return b.String()
+}
+
+// fieldError wraps the error into a FieldError with the current path,
+// unless it already is one (and thus it has a more specific path)
+func (s *mappingState) fieldError(context string, err error) error {
+	var fieldErr *FieldError
+	if errors.As(err, &fieldErr) {
+		return err
+	}
+	return newFieldError(s.currentPath(), context, err)
+}
+
 var defaultTypeConvertMap = map[string]TypeConverterFn{
-	"time.Time": func(value interface{}) interface{} {
-		timeValue := value.(time.Time)
-		parsedTime, _ := time.Parse(time.RFC3339, timeValue.Format(time.RFC3339))
-		return parsedTime
-	},
+	"time.Time": TimeConverter(TimePolicy{}),
 }
 
 func validateParameters(source interface{}, target interface{}) error {
@@ -29,151 +71,500 @@ func validateParameters(source interface{}, target interface{}) error {
 		return fmt.Errorf("invalid source parameter: %w", ErrUnexpectedNil)
 	}
 
-	if reflect.ValueOf(target).Kind() != reflect.Ptr {
+	targetValue := reflect.ValueOf(target)
+	if targetValue.Kind() != reflect.Ptr {
 		return fmt.Errorf("invalid target parameter: %w", ErrMustBePointer)
 	}
+	if targetValue.IsNil() {
+		return fmt.Errorf("invalid target parameter: %w", ErrUnexpectedNil)
+	}
 
 	return nil
 }
 
 // Map copies values from source to target (pointer), and returns an error if any
 func Map(source, target interface{}) error {
-	return MapWithConverters(source, target, defaultTypeConvertMap)
+	return defaultMapper.Map(source, target)
+}
+
+// MapContext copies values from source to target (pointer), and returns an error if any.
+// The context is passed to `fromMethod` methods and context converters, and canceling it stops the mapping
+// (see Mapper.MapContext)
+func MapContext(ctx context.Context, source, target interface{}) error {
+	return defaultMapper.MapContext(ctx, source, target)
 }
 
 // MapWithConverters copies values from source to target (pointer), returns an error if any,
 // and uses the `converters` map to convert custom types as defined by the library consumer
 func MapWithConverters(source, target interface{}, converters map[string]TypeConverterFn) error {
-	if err := validateParameters(source, target); err != nil {
-		return err
-	}
+	return defaultMapper.MapWithConverters(source, target, converters)
+}
 
-	// merge maps
-	converterFnMap := make(map[string]TypeConverterFn, 0)
-	for k, v := range defaultTypeConvertMap {
-		converterFnMap[k] = v
+// mapValues recursively copies values from one object to another using reflection
+func mapValues(sourceValue reflect.Value, targetValue reflect.Value, state *mappingState) (interface{}, error) {
+	// Interface sources are mapped using their dynamic value, the static interface type has no fields
+	for sourceValue.Kind() == reflect.Interface {
+		if sourceValue.IsNil() {
+			return nil, nil
+		}
+		sourceValue = sourceValue.Elem()
 	}
-	for k, v := range converters {
-		converterFnMap[k] = v
+
+	if targetValue.IsValid() {
+		// database/sql types (sql.Null*, sql.Scanner and driver.Valuer)
+		if resolved, ok, err := resolveSQLValue(sourceValue, targetValue, state); ok || err != nil {
+			return resolved, err
+		}
+		var ok bool
+		var err error
+		if sourceValue, ok, err = driverValue(sourceValue, targetValue); err != nil || !ok {
+			return nil, err
+		}
+
+		if newValue, ok, err := mapEnum(sourceValue, targetValue, state); ok || err != nil {
+			state.trace(resolution{ResolutionEnum, fmt.Sprintf("%v -> %v", sourceValue.Type(), targetValue.Type())})
+			return newValue, err
+		}
+
+		// If we have a function to create a value of the target type, use it
+		if fn, ok := state.converter(targetValue.Type()); ok {
+			state.trace(resolution{ResolutionConverter, targetValue.Type().String()})
+			newValue := fn(sourceValue.Interface())
+			if newValue == nil {
+				state.log(slog.LevelDebug, "converter fallback", "the converter returned nil, the target is not set", sourceValue.Type(), targetValue.Type())
+			}
+			if newValue == nil || !targetValue.CanSet() {
+				return newValue, nil
+			}
+			return setConverted(newValue, targetValue)
+		}
 	}
 
-	targetValue := reflect.Indirect(reflect.ValueOf(target))
-	_, err := mapValues(reflect.ValueOf(source), targetValue, &converterFnMap)
-	return err
-}
+	// Types we don't own (e.g. net.IP) know how to parse themselves from text
+	if canUnmarshalText(sourceValue, targetValue) {
+		return mapFromText(sourceValue, targetValue)
+	}
 
-// mapValues recursively copies values from one object to another using reflection
-func mapValues(sourceValue reflect.Value, targetValue reflect.Value, converters *map[string]TypeConverterFn) (interface{}, error) {
 	switch targetValue.Kind() {
 	case reflect.Ptr:
-		return mapToPointer(sourceValue, targetValue, converters)
+		return mapToPointer(sourceValue, targetValue, state)
 	case reflect.Struct:
-		return mapToStruct(sourceValue, targetValue, converters)
+		if state.observeNested() {
+			return mapToObservedStruct(sourceValue, targetValue, state)
+		}
+		return mapToStruct(sourceValue, targetValue, state)
 	case reflect.Slice:
-		return mapToSlice(sourceValue, targetValue, converters)
+		return mapToSlice(sourceValue, targetValue, state)
 	case reflect.String:
-		return mapToString(sourceValue, targetValue)
+		return mapToString(sourceValue, targetValue, state)
+	case reflect.Interface:
+		return mapToInterface(sourceValue, targetValue, state)
+	case reflect.Map:
+		return mapToMap(sourceValue, targetValue, state)
 	case reflect.Invalid:
-		log.Println("mapping invalid value", targetValue)
+		var sourceType reflect.Type
+		if sourceValue.IsValid() {
+			sourceType = sourceValue.Type()
+		}
+		state.log(slog.LevelWarn, "mapping invalid value", "invalid target value", sourceType, nil)
+		return nil, nil
 	default:
 		if targetValue.CanSet() {
-			targetValue.Set(sourceValue)
+			if err := assignValue(sourceValue, targetValue); err != nil {
+				return nil, err
+			}
 		}
 	}
 
 	return targetValue.Interface(), nil
 }
 
+// setConverted sets the value returned by a converter into the target. Converters registered for pointer types
+// may return the element value, which is set through a new pointer. Like mapValues, it returns the element value
+// for pointer targets.
+func setConverted(newValue interface{}, targetValue reflect.Value) (interface{}, error) {
+	converted := reflect.ValueOf(newValue)
+	targetType := targetValue.Type()
+	switch {
+	case converted.Type().AssignableTo(targetType):
+		targetValue.Set(converted)
+		if targetType.Kind() == reflect.Ptr {
+			if converted.IsNil() {
+				return nil, nil
+			}
+			return converted.Elem().Interface(), nil
+		}
+	case targetType.Kind() == reflect.Ptr && converted.Type().AssignableTo(targetType.Elem()):
+		ptr := reflect.New(targetType.Elem())
+		ptr.Elem().Set(converted)
+		targetValue.Set(ptr)
+	default:
+		return nil, fmt.Errorf("the converter for %v returned a %v", targetType, converted.Type())
+	}
+
+	return newValue, nil
+}
+
 // getSourceFieldValue - Gets the source field value with the following rules:
-//  - if a mapper tag exists AND has a fromField property, use that
-//  - if a mapper tag exists AND has a fromMethod property, invoke that method and use that
-//  - else return the source struct's field value (if any)
-//  - if no field is present return a Zero value that will fail an IsValid() check
-func getSourceFieldValue(sourceStruct reflect.Value, targetStructField reflect.StructField) reflect.Value {
-	tag := targetStructField.Tag
-	tags, _ := structtag.Parse(string(tag))
-
-	if mapperTag, _ := tags.Get("mapper"); mapperTag != nil {
-		for _, setting := range strings.Split(mapperTag.Value(), ";") {
-			switch {
-			case strings.HasPrefix(setting, "fromField:"):
-				sourceFieldName := strings.Split(setting, ":")[1]
-				return sourceStruct.FieldByName(sourceFieldName)
-			case strings.HasPrefix(setting, "fromMethod:"):
-				sourceMethodName := strings.Split(setting, ":")[1]
-
-				// Search struct receiver. E.g: func (s PersonTest) GetFullName() string
-				method := sourceStruct.MethodByName(sourceMethodName)
-				if !method.IsValid() {
-					// Search pointer receiver. E.g: func (s *PersonTest) GetFullName() string
-					ptr := reflect.New(sourceStruct.Type())
-					ptr.Elem().Set(sourceStruct)
-					method = ptr.MethodByName(sourceMethodName)
-				}
+//   - if a mapper tag exists AND has a fromField (or toField, when mapping in reverse) property, use that
+//   - if a mapper tag exists AND has a fromMethod property, invoke that method and use that
+//     (an error is returned if the method's trailing error result is not nil)
+//   - else return the source struct's field value (if any), matched by name or by the match tag of the Mapper
+//     (falling back to the Go name of the target field when no source field has the tag name)
+//   - if no field has the same name and a source field has a mapper tag with a toField property naming
+//     the target field, use that
+//   - if no field has the same name, use the naming strategies of the Mapper to find it (if any)
+//   - if the getter fallback is enabled and no field is present, invoke a `Get<Field>` or `<Field>` method (if any)
+//   - if no field is present return a Zero value that will fail an IsValid() check
+func getSourceFieldValue(sourceStruct reflect.Value, targetField field, settings []tagSetting, state *mappingState) (reflect.Value, resolution, error) {
+	for _, setting := range settings {
+		switch setting.option {
+		case "fromField", "toField":
+			kind := ResolutionFromField
+			if setting.option == "toField" {
+				kind = ResolutionToField
+			}
+			return state.mapper.fieldByPath(sourceStruct, setting.value), resolution{kind, setting.value}, nil
+		case "fromMethod":
+			value, err := callSourceMethod(state.ctx, sourceStruct, setting.value)
+			if err != nil || value.IsValid() {
+				return value, resolution{ResolutionFromMethod, setting.value}, err
+			}
+		}
+	}
 
-				if method.IsValid() {
-					values := method.Call(make([]reflect.Value, 0))
-					if len(values) > 0 {
-						return values[0]
-					}
+	name, _ := state.mapper.targetMatchName(targetField)
+	if sourceStruct.Kind() == reflect.Struct {
+		var exists bool
+		name, exists = state.mapper.sourceMatchName(sourceStruct.Type(), targetField)
+		if value := state.mapper.matchFieldByName(sourceStruct, name); value.IsValid() {
+			return value, resolution{ResolutionField, name}, nil
+		}
+
+		// Source fields renamed with toField only apply when the source has no field named like the target field
+		if !exists {
+			if rename, ok := state.mapper.renamedSourceFields(sourceStruct.Type())[targetField.structField.Name]; ok {
+				if rename.err != nil {
+					return reflect.Value{}, resolution{}, rename.err
 				}
+				return state.mapper.fieldByName(sourceStruct, rename.name), resolution{ResolutionSourceTag, rename.name}, nil
 			}
 		}
+
+		match, err := state.mapper.resolveFieldName(sourceStruct.Type(), name)
+		if err != nil {
+			return reflect.Value{}, resolution{}, err
+		}
+		if match != "" {
+			return state.mapper.matchFieldByName(sourceStruct, match), resolution{ResolutionNaming, match}, nil
+		}
+	}
+	if state.getters {
+		value, err := callGetter(state.ctx, sourceStruct, targetField.structField.Name)
+		if err != nil || value.IsValid() {
+			return value, resolution{ResolutionGetter, targetField.structField.Name}, err
+		}
 	}
 
-	return sourceStruct.FieldByName(targetStructField.Name)
+	return reflect.Value{}, resolution{ResolutionField, name}, nil
 }
 
-func mapToStruct(sourceValue, targetValue reflect.Value, converters *map[string]TypeConverterFn) (interface{}, error) {
-	numFields := targetValue.NumField()
-
+func mapToStruct(sourceValue, targetValue reflect.Value, state *mappingState) (interface{}, error) {
 	// Indirect the source value in case it's a pointer to a struct, and not a struct
 	sourceValue = reflect.Indirect(sourceValue)
 
-	for i := 0; i < numFields; i++ {
-		targetField := targetValue.Type().Field(i)
-		targetFieldValue := targetValue.FieldByName(targetField.Name)
-		sourceFieldValue := getSourceFieldValue(sourceValue, targetField)
+	if err := callBeforeMap(targetValue, sourceValue); err != nil {
+		return nil, state.fieldError("BeforeMap hook failed", err)
+	}
+
+	// The fluent configuration for this type pair (if any) takes precedence over struct tags
+	var typeMap *TypeMap
+	if sourceValue.IsValid() {
+		typeMap = state.mapper.config.lookup(sourceValue.Type(), targetValue.Type())
+	}
 
-		// E.g: the field does not exist or is not exported
-		// check CanInterface to see if sourceFieldValue is exported or not
-		// we IGNORE unexported source fields
-		if !sourceFieldValue.IsValid() || !sourceFieldValue.CanInterface() {
+	// Embedded structs mapped as a whole, whose promoted fields are skipped
+	var wholeEmbedded [][]int
+	for _, targetField := range state.mapper.cachedTypeFields(targetValue.Type()).list {
+		if withinAny(targetField.index, wholeEmbedded) {
 			continue
 		}
-
-		var newValue interface{}
-		// If we have a function to create a value of the target type, use it
-		if fn, ok := (*converters)[targetFieldValue.Type().String()]; ok {
-			newValue = fn(sourceFieldValue.Interface())
-		} else {
-			var err error
-			newValue, err = mapValues(sourceFieldValue, targetFieldValue, converters)
-			if err != nil {
-				return nil, newFieldError(targetField.Name, "invalid field projection", err)
+		// Embedded structs are not mapped as a whole, their promoted fields are mapped instead,
+		// unless the source has a plain field with the same name or there's a converter for them
+		if targetField.embedded {
+			if !mapsEmbeddedWhole(sourceValue, targetField, state) {
+				continue
 			}
+			wholeEmbedded = append(wholeEmbedded, targetField.index)
 		}
 
-		// if the new value is nil then we don't need to set anything and thus we move on
-		if newValue == nil {
+		settings, ignored := typeMap.fieldSettings(targetField)
+		if _, ok := state.mapper.targetMatchName(targetField); ignored || !ok {
+			state.skipNamedField(targetField.name, sourceValue, targetField.typ, resolution{kind: SkippedIgnored})
 			continue
 		}
+		if err := mapToStructField(sourceValue, targetValue, targetField, settings, state); err != nil {
+			return nil, err
+		}
+	}
 
-		// if the target field is a pointer, but mapValues only returns actual values (not pointers)
-		// then we should wrap this new value into a pointer to be set into targetFieldValue
-		if targetFieldValue.Kind() == reflect.Ptr {
-			wrapper := reflect.New(reflect.TypeOf(newValue))
-			wrapper.Elem().Set(reflect.ValueOf(newValue))
-			targetFieldValue.Set(wrapper)
-		} else {
-			targetFieldValue.Set(reflect.ValueOf(newValue))
+	// Unexported fields can only be set through their `Set<Field>` method
+	for _, setter := range state.mapper.cachedSetterFields(targetValue.Type()) {
+		settings, ignored := typeMap.fieldSettings(setter.field)
+		if _, ok := state.mapper.targetMatchName(setter.field); ignored || !ok {
+			state.skipNamedField(setter.name, sourceValue, setter.field.typ, resolution{kind: SkippedIgnored})
+			continue
+		}
+		if err := mapToSetter(sourceValue, targetValue, setter, settings, state); err != nil {
+			return nil, err
 		}
 	}
 
+	// Nested target fields configured with a dotted path, e.g. `Address.City`
+	paths, nested := typeMap.nestedFields()
+	for _, path := range paths {
+		if err := mapToFieldPath(sourceValue, targetValue, path, nested[path], state); err != nil {
+			return nil, err
+		}
+	}
+
+	// Source fields configured to be set through a setter of the target, e.g. reversed fromMethod fields
+	for _, call := range typeMap.setterCalls() {
+		setter := setterField{field: field{name: call.field, structField: reflect.StructField{Name: call.field}}, method: call.method}
+		if err := mapToSetter(sourceValue, targetValue, setter, []tagSetting{{option: "fromField", value: call.field}}, state); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := callAfterMap(targetValue, sourceValue); err != nil {
+		return nil, state.fieldError("AfterMap hook failed", err)
+	}
+
 	return targetValue.Interface(), nil
 }
 
-func mapToPointer(sourceValue, targetValue reflect.Value, converters *map[string]TypeConverterFn) (interface{}, error) {
+// mapsEmbeddedWhole reports whether the embedded target struct is mapped as a whole instead of its promoted fields
+func mapsEmbeddedWhole(sourceValue reflect.Value, embedded field, state *mappingState) bool {
+	if !sourceValue.IsValid() {
+		return false
+	}
+	hasConverter := func(t reflect.Type) bool {
+		_, ok := state.converter(t)
+		return ok
+	}
+	return state.mapper.embeddedMappedWhole(sourceValue.Type(), embedded, hasConverter)
+}
+
+// embeddedMappedWhole reports whether the embedded target struct is mapped as a whole from the source type:
+// when there's a converter for it, or the source has a plain field with the same name
+func (m *Mapper) embeddedMappedWhole(sourceType reflect.Type, embedded field, hasConverter func(reflect.Type) bool) bool {
+	if hasConverter(embedded.typ) || hasConverter(indirectType(embedded.typ)) {
+		return true
+	}
+	if sourceType.Kind() != reflect.Struct {
+		return false
+	}
+	// Source structs embedding the same type promote its fields too, so they're mapped one by one
+	name, _ := m.targetMatchName(embedded)
+	fields := m.matchFields(sourceType)
+	i, ok := fields.byName[name]
+	return ok && !fields.list[i].embedded
+}
+
+func withinAny(index []int, embedded [][]int) bool {
+	for _, e := range embedded {
+		if isWithin(index, e) {
+			return true
+		}
+	}
+	return false
+}
+
+func mapToStructField(sourceValue, targetValue reflect.Value, targetField field, settings []tagSetting, state *mappingState) error {
+	state.push(targetField.name)
+	defer state.pop()
+	if err := state.checkCanceled(); err != nil {
+		return err
+	}
+
+	sourceFieldValue, resolved, err := resolveSourceFieldValue(sourceValue, targetField, settings, state)
+	if err != nil {
+		return err
+	}
+	if ok, err := usableSourceField(sourceValue, sourceFieldValue, targetField.typ, resolved, state); !ok {
+		return err
+	}
+	step := state.trace(resolved)
+
+	// Setters declared with the toMethod option are called instead of writing the field
+	if setter, ok := lookupTagSetting(settings, "toMethod"); ok {
+		if err := callSetter(targetValue, setter, sourceFieldValue, state); err != nil {
+			return state.fieldError("invalid setter method", err)
+		}
+		return nil
+	}
+
+	// Promoted fields behind a nil embedded pointer are mapped into a temporary value,
+	// the embedded pointer is only allocated if there's something to set
+	targetFieldValue := fieldByIndex(targetValue, targetField.index, false)
+	if !targetFieldValue.IsValid() {
+		targetFieldValue = reflect.New(targetField.typ).Elem()
+	}
+
+	newValue, err := mapValues(sourceFieldValue, targetFieldValue, state)
+	if err != nil {
+		return state.fieldError("invalid field projection", err)
+	}
+
+	// if the new value is nil then we don't need to set anything and thus we move on
+	if newValue == nil {
+		state.retrace(step, SkippedNil)
+		state.logSkipped(sourceFieldValue, targetField.typ, SkippedNil)
+		return nil
+	}
+
+	targetFieldValue = fieldByIndex(targetValue, targetField.index, true)
+	if !targetFieldValue.IsValid() {
+		return nil
+	}
+
+	// if the target field is a pointer, but mapValues only returns actual values (not pointers)
+	// then we should wrap this new value into a pointer to be set into targetFieldValue
+	if targetFieldValue.Kind() == reflect.Ptr {
+		wrapper := reflect.New(reflect.TypeOf(newValue))
+		wrapper.Elem().Set(reflect.ValueOf(newValue))
+		targetFieldValue.Set(wrapper)
+	} else {
+		targetFieldValue.Set(reflect.ValueOf(newValue))
+	}
+
+	return nil
+}
+
+// mapToFieldPath maps the source into a nested target field with a dotted path (e.g. `Address.City`),
+// allocating the nil pointers found on the way only if there's something to set
+func mapToFieldPath(sourceValue, targetValue reflect.Value, path string, settings []tagSetting, state *mappingState) error {
+	return mapToFieldNames(sourceValue, targetValue, strings.Split(path, "."), settings, state)
+}
+
+func mapToFieldNames(sourceValue, targetValue reflect.Value, names []string, settings []tagSetting, state *mappingState) error {
+	f, ok := state.mapper.fieldNamed(targetValue.Type(), names[0])
+	if !ok {
+		return nil
+	}
+	if len(names) == 1 {
+		return mapToStructField(sourceValue, targetValue, f, settings, state)
+	}
+
+	state.push(names[0])
+	defer state.pop()
+
+	// Structs behind nil pointers are mapped into a temporary value, like promoted fields of nil embedded pointers
+	fieldValue := fieldByIndex(targetValue, f.index, false)
+	temporary := !fieldValue.IsValid() || (fieldValue.Kind() == reflect.Ptr && fieldValue.IsNil())
+	var nestedValue reflect.Value
+	switch {
+	case temporary:
+		nestedValue = reflect.New(indirectType(f.typ)).Elem()
+	case fieldValue.Kind() == reflect.Ptr:
+		nestedValue = fieldValue.Elem()
+	default:
+		nestedValue = fieldValue
+	}
+	if nestedValue.Kind() != reflect.Struct {
+		return nil
+	}
+
+	if err := mapToFieldNames(sourceValue, nestedValue, names[1:], settings, state); err != nil {
+		return err
+	}
+	if !temporary || nestedValue.IsZero() {
+		return nil
+	}
+
+	fieldValue = fieldByIndex(targetValue, f.index, true)
+	if !fieldValue.IsValid() {
+		return nil
+	}
+	if fieldValue.Kind() == reflect.Ptr {
+		wrapper := reflect.New(nestedValue.Type())
+		wrapper.Elem().Set(nestedValue)
+		fieldValue.Set(wrapper)
+	} else {
+		fieldValue.Set(nestedValue)
+	}
+	return nil
+}
+
+func mapToSetter(sourceValue, targetValue reflect.Value, setter setterField, settings []tagSetting, state *mappingState) error {
+	state.push(setter.name)
+	defer state.pop()
+	if err := state.checkCanceled(); err != nil {
+		return err
+	}
+
+	sourceFieldValue, resolved, err := resolveSourceFieldValue(sourceValue, setter.field, settings, state)
+	if err != nil {
+		return err
+	}
+	if ok, err := usableSourceField(sourceValue, sourceFieldValue, setter.field.typ, resolved, state); !ok {
+		return err
+	}
+	resolved.source += " (setter " + setter.method + ")"
+	state.trace(resolved)
+
+	if err := callSetter(targetValue, setter.method, sourceFieldValue, state); err != nil {
+		return state.fieldError("invalid setter method", err)
+	}
+	return nil
+}
+
+// usableSourceField reports whether the source field value should be mapped into the target field.
+// E.g: the field does not exist or is not exported (checked with CanInterface). Those fields are
+// IGNORED, unless the Mapper is strict. Zero values are ignored too when the Mapper merges values.
+func usableSourceField(sourceValue, sourceFieldValue reflect.Value, targetType reflect.Type, resolved resolution, state *mappingState) (bool, error) {
+	if !sourceFieldValue.IsValid() || !sourceFieldValue.CanInterface() {
+		if state.plan != nil || state.logEnabled(slog.LevelDebug) {
+			skipped := SkippedMissing
+			if sourceFieldValue.IsValid() || hasUnexportedField(sourceValue, resolved.source) {
+				skipped = SkippedUnexported
+			}
+			state.skipField(sourceValue, targetType, resolution{skipped, resolved.source})
+		}
+
+		if state.mapper.strict && sourceValue.IsValid() {
+			return false, state.fieldError("missing source field", ErrMissingSourceField)
+		}
+		return false, nil
+	}
+	if state.mapper.merge && sourceFieldValue.IsZero() {
+		state.skipField(sourceValue, targetType, resolution{SkippedZero, resolved.source})
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// resolveSourceFieldValue gets the source value for the target field, and applies the transforms and
+// time conversions declared in the target field settings
+func resolveSourceFieldValue(sourceValue reflect.Value, targetField field, settings []tagSetting, state *mappingState) (reflect.Value, resolution, error) {
+	sourceFieldValue, resolved, err := getSourceFieldValue(sourceValue, targetField, settings, state)
+	if err != nil {
+		return reflect.Value{}, resolved, state.fieldError("invalid source field", err)
+	}
+	sourceFieldValue, err = applyTransforms(sourceFieldValue, settings, state)
+	if err != nil {
+		return reflect.Value{}, resolved, state.fieldError("invalid field transform", err)
+	}
+	sourceFieldValue, err = applyTimeTag(sourceFieldValue, targetField.typ, settings)
+	if err != nil {
+		return reflect.Value{}, resolved, state.fieldError("invalid time conversion", err)
+	}
+
+	return sourceFieldValue, resolved, nil
+}
+
+func mapToPointer(sourceValue, targetValue reflect.Value, state *mappingState) (interface{}, error) {
 	// If source value is a Zero value, there's no value to be copied
 	if sourceValue.IsZero() {
 		return nil, nil
@@ -182,25 +573,58 @@ func mapToPointer(sourceValue, targetValue reflect.Value, converters *map[string
 	// Indirect the source value in case it's a pointer to a struct, and not a struct
 	sourceIndirectValue := reflect.Indirect(sourceValue)
 
-	var newValue interface{}
-	if fn, ok := (*converters)[targetValue.Type().Elem().String()]; ok {
-		newValue = fn(sourceIndirectValue.Interface())
-	} else {
-		// we want to create an artificial target value that
-		//  is NOT a pointer AND IS addressable/settable
-		// so that we can build a value recursively
-		// and after that set a pointer to this new value to the original target
-		targetArtificialValue := reflect.New(targetValue.Type().Elem())
-		newValue, _ = mapValues(sourceIndirectValue, targetArtificialValue.Elem(), converters)
+	// we want to create an artificial target value that
+	//  is NOT a pointer AND IS addressable/settable
+	// so that we can build a value recursively
+	// and after that set a pointer to this new value to the original target
+	targetArtificialValue := reflect.New(targetValue.Type().Elem())
+	newValue, err := mapValues(sourceIndirectValue, targetArtificialValue.Elem(), state)
+	if err != nil {
+		return nil, err
+	}
+
+	// set the pointer when the target is settable, e.g. slice items or map values
+	if newValue != nil && targetValue.CanSet() {
+		targetValue.Set(targetArtificialValue)
 	}
 
 	// return the actual value (not a pointer, to avoid returning a *interface{} type)
 	return newValue, nil
 }
 
-func mapToString(sourceValue, targetValue reflect.Value) (interface{}, error) {
+// assignValue sets the source value into the target, converting it when their types are compatible
+func assignValue(sourceValue, targetValue reflect.Value) error {
+	switch {
+	case !sourceValue.IsValid():
+		return nil
+	case sourceValue.Type().AssignableTo(targetValue.Type()):
+		targetValue.Set(sourceValue)
+	case sourceValue.Type().ConvertibleTo(targetValue.Type()):
+		targetValue.Set(sourceValue.Convert(targetValue.Type()))
+	default:
+		return fmt.Errorf("cannot assign %v to %v", sourceValue.Type(), targetValue.Type())
+	}
+
+	return nil
+}
+
+func mapToString(sourceValue, targetValue reflect.Value, state *mappingState) (interface{}, error) {
+	if sourceValue.Kind() == reflect.Ptr {
+		if sourceValue.IsNil() {
+			return nil, nil
+		}
+		sourceValue = sourceValue.Elem()
+	}
+
+	if state.logEnabled(slog.LevelDebug) && formattedWithFmt(sourceValue) {
+		state.log(slog.LevelDebug, "converter fallback", "no converter for the type, formatted with fmt", sourceValue.Type(), targetValue.Type())
+	}
+
 	// attempt conversion to string
-	var sourceValueStr string = fmt.Sprintf("%v", sourceValue.Interface())
+	sourceValueStr, err := formatText(sourceValue)
+	if err != nil {
+		return nil, err
+	}
 	if targetValue.CanSet() {
 		targetValue.Set(reflect.ValueOf(sourceValueStr))
 	}
@@ -208,7 +632,7 @@ func mapToString(sourceValue, targetValue reflect.Value) (interface{}, error) {
 	return targetValue.Interface(), nil
 }
 
-func mapToSlice(sourceValue, targetValue reflect.Value, converters *map[string]TypeConverterFn) (interface{}, error) {
+func mapToSlice(sourceValue, targetValue reflect.Value, state *mappingState) (interface{}, error) {
 	if !sourceValue.IsValid() {
 		return nil, nil
 	}
@@ -220,10 +644,93 @@ func mapToSlice(sourceValue, targetValue reflect.Value, converters *map[string]T
 
 	numItems := sourceValue.Len()
 	targetSlice := reflect.MakeSlice(targetValue.Type(), numItems, numItems)
-	for i := 0; i < numItems; i++ {
-		mapValues(sourceValue.Index(i), targetSlice.Index((i)), converters)
+	if state.parallelSlice(numItems) {
+		if err := mapSliceItemsInParallel(sourceValue, targetSlice, state); err != nil {
+			return nil, err
+		}
+	} else {
+		allErrors := state.mapper.parallel != nil && state.mapper.parallel.AllErrors
+		var errs []error
+		for i := 0; i < numItems; i++ {
+			if err := mapSliceItem(sourceValue, targetSlice, i, state); err != nil {
+				// The remaining elements would fail too once the context is done
+				if !allErrors || state.ctx.Err() != nil {
+					return nil, err
+				}
+				errs = append(errs, err)
+			}
+		}
+		if len(errs) > 0 {
+			return nil, errors.Join(errs...)
+		}
 	}
 
 	targetValue.Set(reflect.ValueOf(targetSlice.Interface()))
 	return targetValue.Interface(), nil
 }
+
+func mapSliceItem(sourceValue, targetSlice reflect.Value, i int, state *mappingState) error {
+	state.push(fmt.Sprintf("[%d]", i))
+	defer state.pop()
+	if err := state.checkCanceled(); err != nil {
+		return err
+	}
+	state.elements++
+
+	if _, err := mapValues(sourceValue.Index(i), targetSlice.Index(i), state); err != nil {
+		return state.fieldError("invalid slice item", err)
+	}
+	return nil
+}
+
+func mapToMap(sourceValue, targetValue reflect.Value, state *mappingState) (interface{}, error) {
+	sourceValue = reflect.Indirect(sourceValue)
+	if !sourceValue.IsValid() || (sourceValue.Kind() == reflect.Map && sourceValue.IsNil()) {
+		return nil, nil
+	}
+
+	// Maps with compatible types are copied as they are
+	if sourceValue.Kind() != reflect.Map || sourceValue.Type().AssignableTo(targetValue.Type()) {
+		if targetValue.CanSet() {
+			if err := assignValue(sourceValue, targetValue); err != nil {
+				return nil, err
+			}
+		}
+		return targetValue.Interface(), nil
+	}
+
+	keyType, elemType := targetValue.Type().Key(), targetValue.Type().Elem()
+	targetMap := reflect.MakeMapWithSize(targetValue.Type(), sourceValue.Len())
+	iter := sourceValue.MapRange()
+	for iter.Next() {
+		key := reflect.New(keyType).Elem()
+		elem := reflect.New(elemType).Elem()
+		if err := mapToMapEntry(iter.Key(), iter.Value(), key, elem, state); err != nil {
+			return nil, err
+		}
+		targetMap.SetMapIndex(key, elem)
+	}
+
+	if targetValue.CanSet() {
+		targetValue.Set(targetMap)
+	}
+	return targetValue.Interface(), nil
+}
+
+func mapToMapEntry(sourceKey, sourceElem, targetKey, targetElem reflect.Value, state *mappingState) error {
+	state.push(fmt.Sprintf("[%v]", sourceKey))
+	defer state.pop()
+	if err := state.checkCanceled(); err != nil {
+		return err
+	}
+	state.elements++
+
+	if _, err := mapValues(sourceKey, targetKey, state); err != nil {
+		return state.fieldError("invalid map key", err)
+	}
+	if _, err := mapValues(sourceElem, targetElem, state); err != nil {
+		return state.fieldError("invalid map value", err)
+	}
+
+	return nil
+}
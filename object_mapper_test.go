@@ -1,8 +1,18 @@
 package mapper
 
 import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
+	"log/slog"
+	"net"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -592,6 +602,11 @@ func Test_returnsErrWhenNilParam(t *testing.T) {
 	err = Map(nil, target)
 	assert.Error(t, err)
 	assert.ErrorIs(t, err, ErrUnexpectedNil)
+
+	// Nil pointer target
+	err = Map(source, (*Person)(nil))
+	assert.ErrorIs(t, err, ErrUnexpectedNil)
+	assert.Contains(t, err.Error(), "invalid target parameter")
 }
 
 func Test_returnsErrWhenTargetNotPointer(t *testing.T) {
@@ -706,3 +721,2115 @@ func Test_mapStructWithUnexportedFields(t *testing.T) {
 	expected := Target{name: "", age: 0}
 	assert.Equal(t, expected, target)
 }
+
+type BaseEntity struct {
+	ID        int
+	CreatedAt time.Time
+}
+
+type AuditInfo struct {
+	ID        int
+	CreatedBy string
+}
+
+func Test_mapStructWithEmbeddedSource(t *testing.T) {
+	type User struct {
+		BaseEntity
+		Name string
+	}
+
+	type UserDTO struct {
+		ID        int
+		CreatedAt time.Time
+		Name      string
+	}
+
+	created, _ := time.Parse(time.RFC3339, time.RFC3339)
+	source := User{BaseEntity: BaseEntity{ID: 10, CreatedAt: created}, Name: "John"}
+	target := UserDTO{}
+	err := Map(source, &target)
+	assert.Nil(t, err)
+
+	expected := UserDTO{ID: 10, CreatedAt: created, Name: "John"}
+	assert.Equal(t, expected, target)
+}
+
+func Test_mapStructWithEmbeddedNilPointerSource(t *testing.T) {
+	type User struct {
+		*BaseEntity
+		Name string
+	}
+
+	type UserDTO struct {
+		ID   int
+		Name string
+	}
+
+	source := User{Name: "John"}
+	target := UserDTO{}
+	err := Map(source, &target)
+	assert.Nil(t, err)
+
+	expected := UserDTO{Name: "John"}
+	assert.Equal(t, expected, target)
+}
+
+func Test_mapStructWithEmbeddedTarget(t *testing.T) {
+	type UserDTO struct {
+		ID   int
+		Name string
+	}
+
+	type User struct {
+		BaseEntity
+		Name string
+	}
+
+	source := UserDTO{ID: 10, Name: "John"}
+	target := User{}
+	err := Map(source, &target)
+	assert.Nil(t, err)
+
+	expected := User{BaseEntity: BaseEntity{ID: 10}, Name: "John"}
+	assert.Equal(t, expected, target)
+}
+
+func Test_mapStructWithEmbeddedPointerTarget(t *testing.T) {
+	type UserDTO struct {
+		ID   int
+		Name string
+	}
+
+	type User struct {
+		*BaseEntity
+		Name string
+	}
+
+	// The embedded pointer is allocated when one of its promoted fields is set
+	source := UserDTO{ID: 10, Name: "John"}
+	target := User{}
+	err := Map(source, &target)
+	assert.Nil(t, err)
+
+	expected := User{BaseEntity: &BaseEntity{ID: 10}, Name: "John"}
+	assert.Equal(t, expected, target)
+
+	// It's left nil when there's nothing to set
+	type NamedDTO struct {
+		Name string
+	}
+
+	target = User{}
+	err = Map(NamedDTO{Name: "John"}, &target)
+	assert.Nil(t, err)
+	assert.Nil(t, target.BaseEntity)
+}
+
+func Test_mapStructWithEmbeddedOnBothSides(t *testing.T) {
+	type User struct {
+		*BaseEntity
+		Name string
+	}
+
+	type UserDTO struct {
+		BaseEntity
+		Name string
+	}
+
+	created, _ := time.Parse(time.RFC3339, time.RFC3339)
+	source := User{BaseEntity: &BaseEntity{ID: 10, CreatedAt: created}, Name: "John"}
+	target := UserDTO{}
+	err := Map(source, &target)
+	assert.Nil(t, err)
+
+	expected := UserDTO{BaseEntity: BaseEntity{ID: 10, CreatedAt: created}, Name: "John"}
+	assert.Equal(t, expected, target)
+}
+
+func Test_mapStructWithEmbeddedTargetFromPlainField(t *testing.T) {
+	type Source struct {
+		BaseEntity BaseEntity
+		Name       string
+	}
+	type Target struct {
+		BaseEntity
+		Name string
+	}
+
+	m := New(WithStrict())
+	created := time.Date(2021, 10, 5, 12, 0, 0, 0, time.UTC)
+	target := Target{}
+	err := m.Map(Source{BaseEntity: BaseEntity{ID: 10, CreatedAt: created}, Name: "John"}, &target)
+	assert.Nil(t, err)
+	assert.Equal(t, Target{BaseEntity: BaseEntity{ID: 10, CreatedAt: created}, Name: "John"}, target)
+	assert.Nil(t, m.Validate(Pair[Source, Target]()))
+}
+
+func Test_mapStructWithEmbeddedTypeWithoutExportedFields(t *testing.T) {
+	type Event struct {
+		time.Time
+		Name string
+	}
+
+	created := time.Date(2021, 10, 5, 12, 0, 0, 0, time.FixedZone("ART", -3*60*60))
+	target := Event{}
+	err := Map(Event{Time: created, Name: "created"}, &target)
+	assert.Nil(t, err)
+	assert.Equal(t, Event{Time: created, Name: "created"}, target)
+
+	// The converter registered for the embedded type is used
+	target = Event{}
+	m := New(WithTimePolicy(TimePolicy{Location: time.UTC}))
+	err = m.Map(Event{Time: created, Name: "created"}, &target)
+	assert.Nil(t, err)
+	assert.Equal(t, time.UTC, target.Time.Location())
+	assert.True(t, created.Equal(target.Time))
+}
+
+func Test_mapStructWithAmbiguousEmbeddedFields(t *testing.T) {
+	type Source struct {
+		BaseEntity
+		AuditInfo
+		Name string
+	}
+
+	type Target struct {
+		ID        int
+		CreatedBy string
+		Name      string
+	}
+
+	// ID is ambiguous in Source, so it's treated as missing
+	source := Source{BaseEntity: BaseEntity{ID: 10}, AuditInfo: AuditInfo{ID: 20, CreatedBy: "admin"}, Name: "John"}
+	target := Target{}
+	err := Map(source, &target)
+	assert.Nil(t, err)
+
+	expected := Target{CreatedBy: "admin", Name: "John"}
+	assert.Equal(t, expected, target)
+
+	// A field declared at a shallower depth hides the promoted ones
+	type ShallowSource struct {
+		BaseEntity
+		AuditInfo
+		ID int
+	}
+
+	target = Target{}
+	err = Map(ShallowSource{BaseEntity: BaseEntity{ID: 10}, AuditInfo: AuditInfo{ID: 20}, ID: 30}, &target)
+	assert.Nil(t, err)
+	assert.Equal(t, 30, target.ID)
+
+	// At the same depth, a field with a mapper tag wins over untagged ones
+	type TaggedBase struct {
+		ID int `mapper:"fromField:Code"`
+	}
+	type TaggedTarget struct {
+		BaseEntity
+		TaggedBase
+	}
+
+	tagged := TaggedTarget{}
+	err = Map(struct{ Code int }{Code: 5}, &tagged)
+	assert.Nil(t, err)
+	assert.Equal(t, 5, tagged.TaggedBase.ID)
+	assert.Equal(t, 0, tagged.BaseEntity.ID)
+}
+
+type Shape interface {
+	Area() float64
+}
+
+type Circle struct {
+	Radius float64
+}
+
+func (c Circle) Area() float64 {
+	return 3 * c.Radius * c.Radius
+}
+
+type Square struct {
+	Side float64
+}
+
+func (s *Square) Area() float64 {
+	return s.Side * s.Side
+}
+
+type ShapeDTO interface {
+	Kind() string
+}
+
+type CircleDTO struct {
+	Radius float64
+}
+
+func (c CircleDTO) Kind() string {
+	return "circle"
+}
+
+type SquareDTO struct {
+	Kind_ string
+	Side  float64
+}
+
+func (s *SquareDTO) Kind() string {
+	return s.Kind_
+}
+
+func Test_mapStructWithInterfaceSource(t *testing.T) {
+	type Payload struct {
+		Name string
+	}
+
+	type Source struct {
+		Data  interface{}
+		Shape Shape
+	}
+
+	type PayloadDTO struct {
+		Name string
+	}
+
+	type Target struct {
+		Data  *PayloadDTO
+		Shape Circle
+	}
+
+	source := Source{Data: Payload{Name: "John"}, Shape: Circle{Radius: 2}}
+	target := Target{}
+	err := Map(source, &target)
+	assert.Nil(t, err)
+
+	expected := Target{Data: &PayloadDTO{Name: "John"}, Shape: Circle{Radius: 2}}
+	assert.Equal(t, expected, target)
+
+	// Nil interfaces are left with their zero value
+	target = Target{}
+	err = Map(Source{}, &target)
+	assert.Nil(t, err)
+	assert.Equal(t, Target{}, target)
+}
+
+func Test_mapStructWithAssignableInterfaceTarget(t *testing.T) {
+	type Source struct {
+		Shape Circle
+		Value int
+	}
+
+	type Target struct {
+		Shape Shape
+		Value interface{}
+	}
+
+	source := Source{Shape: Circle{Radius: 2}, Value: 10}
+	target := Target{}
+	err := Map(source, &target)
+	assert.Nil(t, err)
+
+	expected := Target{Shape: Circle{Radius: 2}, Value: 10}
+	assert.Equal(t, expected, target)
+}
+
+func Test_mapSliceOfInterfacesWithRegisteredConcreteTypes(t *testing.T) {
+	m := New()
+	shapeDTOType := reflect.TypeOf((*ShapeDTO)(nil)).Elem()
+	m.RegisterConcreteType(shapeDTOType, reflect.TypeOf(Circle{}), reflect.TypeOf(CircleDTO{}))
+	m.RegisterInterfaceFactory(shapeDTOType, reflect.TypeOf(Square{}), func(source interface{}) interface{} {
+		return &SquareDTO{Kind_: "square"}
+	})
+
+	shapes := []Shape{Circle{Radius: 1}, &Square{Side: 2}, nil}
+	dtos := []ShapeDTO{}
+	err := m.Map(shapes, &dtos)
+	assert.Nil(t, err)
+
+	expected := []ShapeDTO{CircleDTO{Radius: 1}, &SquareDTO{Kind_: "square", Side: 2}, nil}
+	assert.Equal(t, expected, dtos)
+}
+
+func Test_returnsErrWhenNoConcreteTypeRegistered(t *testing.T) {
+	type Triangle struct {
+		Base float64
+	}
+
+	type Source struct {
+		Shape interface{}
+	}
+
+	type Target struct {
+		Shape ShapeDTO
+	}
+
+	err := Map(Source{Shape: Triangle{Base: 1}}, &Target{})
+	assert.Error(t, err)
+	assert.ErrorIs(t, err, ErrNoConcreteType)
+
+	assert.Panics(t, func() {
+		New().RegisterConcreteType(reflect.TypeOf((*ShapeDTO)(nil)).Elem(), reflect.TypeOf(Triangle{}), reflect.TypeOf(Triangle{}))
+	})
+}
+
+type Event interface {
+	EventName() string
+}
+
+type OrderCreatedDTO struct {
+	OrderID int
+	Total   float64
+}
+
+func (e OrderCreatedDTO) EventName() string {
+	return "order.created"
+}
+
+type OrderCanceledDTO struct {
+	OrderID int
+	Reason  string
+}
+
+func (e *OrderCanceledDTO) EventName() string {
+	return "order.canceled"
+}
+
+type OrderPayload struct {
+	OrderID int
+	Total   float64
+	Reason  string
+}
+
+type EventEnvelope struct {
+	Type    string
+	Payload interface{}
+}
+
+func Test_mapSliceOfDiscriminatedUnions(t *testing.T) {
+	m := New()
+	m.RegisterDiscriminator(reflect.TypeOf((*Event)(nil)).Elem(), reflect.TypeOf(EventEnvelope{}), Discriminator{
+		Field:        "Type",
+		PayloadField: "Payload",
+		Types: map[string]reflect.Type{
+			"order.created":  reflect.TypeOf(OrderCreatedDTO{}),
+			"order.canceled": reflect.TypeOf(&OrderCanceledDTO{}),
+		},
+	})
+
+	envelopes := []EventEnvelope{
+		{Type: "order.created", Payload: OrderPayload{OrderID: 1, Total: 10.5}},
+		{Type: "order.canceled", Payload: &OrderPayload{OrderID: 1, Reason: "out of stock"}},
+	}
+	events := []Event{}
+	err := m.Map(envelopes, &events)
+	assert.Nil(t, err)
+
+	expected := []Event{
+		OrderCreatedDTO{OrderID: 1, Total: 10.5},
+		&OrderCanceledDTO{OrderID: 1, Reason: "out of stock"},
+	}
+	assert.Equal(t, expected, events)
+
+	// Unknown discriminator values are reported
+	err = m.Map([]EventEnvelope{{Type: "order.shipped"}}, &events)
+	assert.Error(t, err)
+	assert.ErrorIs(t, err, ErrUnknownDiscriminator)
+}
+
+func Test_mapDiscriminatedUnionFromNilPointer(t *testing.T) {
+	m := New()
+	m.RegisterDiscriminator(reflect.TypeOf((*Event)(nil)).Elem(), reflect.TypeOf(EventEnvelope{}), Discriminator{
+		Field:        "Type",
+		PayloadField: "Payload",
+		Types: map[string]reflect.Type{
+			"order.created": reflect.TypeOf(OrderCreatedDTO{}),
+		},
+	})
+
+	events := []Event{}
+	err := m.Map([]*EventEnvelope{{Type: "order.created", Payload: OrderPayload{OrderID: 1}}, nil}, &events)
+	assert.Nil(t, err)
+	assert.Equal(t, []Event{OrderCreatedDTO{OrderID: 1}, nil}, events)
+}
+
+func Test_returnsErrWhenDiscriminatorPayloadFieldIsMissing(t *testing.T) {
+	m := New()
+	m.RegisterDiscriminator(reflect.TypeOf((*Event)(nil)).Elem(), reflect.TypeOf(EventEnvelope{}), Discriminator{
+		Field:        "Type",
+		PayloadField: "Paylod",
+		Types: map[string]reflect.Type{
+			"order.created": reflect.TypeOf(OrderCreatedDTO{}),
+		},
+	})
+
+	events := []Event{}
+	err := m.Map([]EventEnvelope{{Type: "order.created", Payload: OrderPayload{OrderID: 1}}}, &events)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "discriminator payload field Paylod not found")
+}
+
+func Test_mapDiscriminatedUnionWithoutPayloadField(t *testing.T) {
+	type Notification interface{}
+
+	type EmailNotification struct {
+		Kind    string
+		Address string
+	}
+
+	type SMSNotification struct {
+		Kind  string
+		Phone string
+	}
+
+	type FlatNotification struct {
+		Kind    string
+		Address string
+		Phone   string
+	}
+
+	type Target struct {
+		Notification Notification
+	}
+
+	m := New()
+	m.RegisterDiscriminator(reflect.TypeOf((*Notification)(nil)).Elem(), reflect.TypeOf(FlatNotification{}), Discriminator{
+		Field: "Kind",
+		Types: map[string]reflect.Type{
+			"email": reflect.TypeOf(EmailNotification{}),
+			"sms":   reflect.TypeOf(SMSNotification{}),
+		},
+	})
+
+	type Source struct {
+		Notification FlatNotification
+	}
+
+	target := Target{}
+	err := m.Map(Source{Notification: FlatNotification{Kind: "sms", Phone: "555-1234"}}, &target)
+	assert.Nil(t, err)
+
+	expected := Target{Notification: SMSNotification{Kind: "sms", Phone: "555-1234"}}
+	assert.Equal(t, expected, target)
+}
+
+type Version struct {
+	Major int
+	Minor int
+}
+
+func (v Version) String() string {
+	return fmt.Sprintf("v%d.%d", v.Major, v.Minor)
+}
+
+func (v *Version) UnmarshalText(text []byte) error {
+	_, err := fmt.Sscanf(string(text), "v%d.%d", &v.Major, &v.Minor)
+	return err
+}
+
+func Test_mapStructWithTextMarshalerToString(t *testing.T) {
+	type Source struct {
+		IP      net.IP
+		Version Version
+		Name    *string
+	}
+
+	type Target struct {
+		IP      string
+		Version string
+		Name    string
+	}
+
+	name := "John"
+	source := Source{IP: net.IPv4(10, 0, 0, 1), Version: Version{1, 2}, Name: &name}
+	target := Target{}
+	err := Map(source, &target)
+	assert.Nil(t, err)
+
+	expected := Target{IP: "10.0.0.1", Version: "v1.2", Name: "John"}
+	assert.Equal(t, expected, target)
+}
+
+func Test_mapStructWithTextUnmarshalerTarget(t *testing.T) {
+	type Source struct {
+		IP      string
+		Version []byte
+		Backup  string
+	}
+
+	type Target struct {
+		IP      net.IP
+		Version Version
+		Backup  *net.IP
+	}
+
+	source := Source{IP: "10.0.0.1", Version: []byte("v1.2"), Backup: "10.0.0.2"}
+	target := Target{}
+	err := Map(source, &target)
+	assert.Nil(t, err)
+
+	backup := net.IPv4(10, 0, 0, 2)
+	expected := Target{IP: net.IPv4(10, 0, 0, 1), Version: Version{1, 2}, Backup: &backup}
+	assert.Equal(t, expected, target)
+
+	// Parsing errors are reported
+	err = Map(Source{IP: "not-an-ip"}, &target)
+	assert.Error(t, err)
+}
+
+func Test_mapStructFromSQLNullTypes(t *testing.T) {
+	type UserRow struct {
+		Name      sql.NullString
+		Nickname  sql.NullString
+		Age       sql.NullInt64
+		Score     sql.NullInt32
+		DeletedAt sql.NullTime
+		UpdatedAt sql.NullTime
+	}
+
+	type UserDTO struct {
+		Name      *string
+		Nickname  *string
+		Age       int64
+		Score     int
+		DeletedAt *time.Time
+		UpdatedAt time.Time
+	}
+
+	updated, _ := time.Parse(time.RFC3339, time.RFC3339)
+	source := UserRow{
+		Name:      sql.NullString{String: "John", Valid: true},
+		Nickname:  sql.NullString{String: "stale", Valid: false},
+		Age:       sql.NullInt64{Int64: 30, Valid: true},
+		Score:     sql.NullInt32{Int32: 80, Valid: true},
+		UpdatedAt: sql.NullTime{Time: updated, Valid: true},
+	}
+	target := UserDTO{}
+	err := Map(source, &target)
+	assert.Nil(t, err)
+
+	name := "John"
+	expected := UserDTO{Name: &name, Age: 30, Score: 80, UpdatedAt: updated}
+	assert.Equal(t, expected, target)
+}
+
+func Test_mapStructToSQLNullTypes(t *testing.T) {
+	type UserDTO struct {
+		Name      *string
+		Nickname  *string
+		Age       int
+		DeletedAt *time.Time
+		UpdatedAt time.Time
+	}
+
+	type UserRow struct {
+		Name      sql.NullString
+		Nickname  sql.NullString
+		Age       sql.NullInt64
+		DeletedAt sql.NullTime
+		UpdatedAt sql.NullTime
+	}
+
+	updated, _ := time.Parse(time.RFC3339, time.RFC3339)
+	name := "John"
+	source := UserDTO{Name: &name, Age: 30, UpdatedAt: updated}
+	target := UserRow{}
+	err := Map(source, &target)
+	assert.Nil(t, err)
+
+	expected := UserRow{
+		Name:      sql.NullString{String: "John", Valid: true},
+		Age:       sql.NullInt64{Int64: 30, Valid: true},
+		UpdatedAt: sql.NullTime{Time: updated, Valid: true},
+	}
+	assert.Equal(t, expected, target)
+}
+
+// Money is stored as cents in the database
+type Money struct {
+	Cents int64
+}
+
+func (m Money) Value() (driver.Value, error) {
+	return m.Cents, nil
+}
+
+func (m *Money) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case int64:
+		m.Cents = v
+	case float64:
+		m.Cents = int64(v * 100)
+	default:
+		return fmt.Errorf("cannot scan %T into Money", src)
+	}
+	return nil
+}
+
+type Tag struct {
+	Name string
+}
+
+// Tags are stored as JSON in the database
+type Tags []Tag
+
+func (t Tags) Value() (driver.Value, error) {
+	data, err := json.Marshal([]Tag(t))
+	return string(data), err
+}
+
+func Test_mapSliceValuerIntoSlice(t *testing.T) {
+	type TagDTO struct {
+		Name string
+	}
+
+	// Valuers are mapped as usual into targets other than basic ones
+	target := []TagDTO{}
+	err := Map(Tags{{Name: "a"}}, &target)
+	assert.Nil(t, err)
+	assert.Equal(t, []TagDTO{{Name: "a"}}, target)
+
+	// and unwrapped into basic ones
+	var text string
+	err = Map(Tags{{Name: "a"}}, &text)
+	assert.Nil(t, err)
+	assert.Equal(t, `[{"Name":"a"}]`, text)
+}
+
+func Test_mapStructWithValuerAndScanner(t *testing.T) {
+	type Order struct {
+		Total Money
+	}
+
+	type OrderDTO struct {
+		Total int64
+	}
+
+	target := OrderDTO{}
+	err := Map(Order{Total: Money{Cents: 1050}}, &target)
+	assert.Nil(t, err)
+	assert.Equal(t, OrderDTO{Total: 1050}, target)
+
+	type OrderInput struct {
+		Total float64
+	}
+
+	order := Order{}
+	err = Map(OrderInput{Total: 10.5}, &order)
+	assert.Nil(t, err)
+	assert.Equal(t, Order{Total: Money{Cents: 1050}}, order)
+
+	// Scan errors are reported
+	type InvalidInput struct {
+		Total bool
+	}
+
+	err = Map(InvalidInput{Total: true}, &order)
+	assert.Error(t, err)
+}
+
+func Test_mapStructWithTimeKeepsPrecision(t *testing.T) {
+	type Event struct {
+		OccurredAt time.Time
+	}
+
+	source := Event{OccurredAt: time.Now()}
+	target := Event{}
+	err := Map(source, &target)
+	assert.Nil(t, err)
+
+	// Nanoseconds and the monotonic clock reading are kept
+	assert.Equal(t, source.OccurredAt, target.OccurredAt)
+	assert.Equal(t, source.OccurredAt.Nanosecond(), target.OccurredAt.Nanosecond())
+}
+
+func Test_mapStructWithTimePolicy(t *testing.T) {
+	type Event struct {
+		OccurredAt time.Time
+		DeletedAt  *time.Time
+	}
+
+	location := time.FixedZone("UTC-3", -3*60*60)
+	occurred := time.Date(2021, 10, 5, 12, 30, 15, 123456789, location)
+	source := Event{OccurredAt: occurred, DeletedAt: &occurred}
+	target := Event{}
+	err := MapWithConverters(source, &target, map[string]TypeConverterFn{
+		"time.Time": TimeConverter(TimePolicy{Truncate: time.Millisecond, Location: time.UTC}),
+	})
+	assert.Nil(t, err)
+
+	expected := time.Date(2021, 10, 5, 15, 30, 15, 123000000, time.UTC)
+	assert.Equal(t, expected, target.OccurredAt)
+	assert.Equal(t, expected, *target.DeletedAt)
+}
+
+func Test_mapStructWithTimeTags(t *testing.T) {
+	type Event struct {
+		Date       time.Time
+		OccurredAt time.Time
+		CreatedAt  time.Time
+		DeletedAt  *time.Time
+	}
+
+	type EventDTO struct {
+		Date       string `mapper:"layout:2006-01-02"`
+		OccurredAt int64  `mapper:"unix"`
+		CreatedAt  int64  `mapper:"unixMilli"`
+		DeletedAt  string `mapper:"layout:2006-01-02T15:04:05Z07:00"`
+	}
+
+	date := time.Date(2021, 10, 5, 12, 30, 15, 500000000, time.UTC)
+	source := Event{Date: date, OccurredAt: date, CreatedAt: date, DeletedAt: &date}
+	target := EventDTO{}
+	err := Map(source, &target)
+	assert.Nil(t, err)
+
+	expected := EventDTO{Date: "2021-10-05", OccurredAt: 1633437015, CreatedAt: 1633437015500, DeletedAt: "2021-10-05T12:30:15Z"}
+	assert.Equal(t, expected, target)
+
+	type EventInput struct {
+		Date       string
+		OccurredAt int64
+		CreatedAt  int64
+		DeletedAt  string
+	}
+
+	type ParsedEvent struct {
+		Date       time.Time  `mapper:"layout:2006-01-02"`
+		OccurredAt time.Time  `mapper:"unix"`
+		CreatedAt  *time.Time `mapper:"unixMilli"`
+		DeletedAt  *time.Time `mapper:"layout:2006-01-02T15:04:05Z07:00"`
+	}
+
+	parsed := ParsedEvent{}
+	err = Map(EventInput{Date: "2021-10-05", OccurredAt: 1633437015, CreatedAt: 1633437015500, DeletedAt: "2021-10-05T12:30:15Z"}, &parsed)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2021, 10, 5, 0, 0, 0, 0, time.UTC), parsed.Date)
+	assert.True(t, date.Truncate(time.Second).Equal(parsed.OccurredAt))
+	assert.True(t, date.Equal(*parsed.CreatedAt))
+	assert.True(t, date.Truncate(time.Second).Equal(*parsed.DeletedAt))
+
+	// Invalid layouts are reported
+	err = Map(EventInput{Date: "05/10/2021"}, &parsed)
+	assert.Error(t, err)
+}
+
+type Status int
+
+const (
+	StatusActive Status = iota
+	StatusBlocked
+	StatusDeleted
+)
+
+type Priority int
+
+const (
+	PriorityLow Priority = iota
+	PriorityHigh
+)
+
+func Test_mapStructWithEnums(t *testing.T) {
+	m := New()
+	m.RegisterEnum(map[Status]string{
+		StatusActive:  "active",
+		StatusBlocked: "blocked",
+	})
+
+	type Account struct {
+		Status   Status
+		History  []Status
+		Counts   map[Status]int
+		Previous *Status
+	}
+
+	type AccountDTO struct {
+		Status   string
+		History  []string
+		Counts   map[string]int
+		Previous *string
+	}
+
+	previous := StatusActive
+	source := Account{
+		Status:   StatusBlocked,
+		History:  []Status{StatusActive, StatusBlocked},
+		Counts:   map[Status]int{StatusActive: 2, StatusBlocked: 1},
+		Previous: &previous,
+	}
+	target := AccountDTO{}
+	err := m.Map(source, &target)
+	assert.Nil(t, err)
+
+	active := "active"
+	expected := AccountDTO{
+		Status:   "blocked",
+		History:  []string{"active", "blocked"},
+		Counts:   map[string]int{"active": 2, "blocked": 1},
+		Previous: &active,
+	}
+	assert.Equal(t, expected, target)
+
+	// The same table is used in the other direction
+	account := Account{}
+	err = m.Map(target, &account)
+	assert.Nil(t, err)
+	assert.Equal(t, source, account)
+
+	// Unknown values are reported by default
+	err = m.Map(Account{Status: StatusDeleted}, &target)
+	assert.Error(t, err)
+	assert.ErrorIs(t, err, ErrUnknownEnumValue)
+
+	err = m.Map(AccountDTO{Status: "archived"}, &account)
+	assert.ErrorIs(t, err, ErrUnknownEnumValue)
+}
+
+func Test_mapStructWithEnumPolicies(t *testing.T) {
+	m := New()
+	m.RegisterEnumWithPolicy(map[Priority]string{PriorityLow: "low", PriorityHigh: "high"}, UnknownEnumZero)
+
+	type Task struct {
+		Priority Priority
+	}
+
+	type TaskDTO struct {
+		Priority string
+	}
+
+	target := TaskDTO{Priority: "previous"}
+	err := m.Map(Task{Priority: Priority(7)}, &target)
+	assert.Nil(t, err)
+	assert.Equal(t, TaskDTO{}, target)
+
+	m = New()
+	m.RegisterEnumWithPolicy(map[Priority]string{PriorityLow: "low", PriorityHigh: "high"}, UnknownEnumPassthrough)
+
+	err = m.Map(Task{Priority: Priority(7)}, &target)
+	assert.Nil(t, err)
+	assert.Equal(t, TaskDTO{Priority: "7"}, target)
+}
+
+func Test_mapSliceOfPointers(t *testing.T) {
+	type Country struct {
+		Name string
+	}
+
+	type Region struct {
+		Name string
+	}
+
+	countries := []*Country{{Name: "Argentina"}, nil, {Name: "USA"}}
+	regions := []*Region{}
+	err := Map(countries, &regions)
+	assert.Nil(t, err)
+
+	expected := []*Region{{Name: "Argentina"}, nil, {Name: "USA"}}
+	assert.Equal(t, expected, regions)
+}
+
+func Test_mapStructWithTransforms(t *testing.T) {
+	m := New()
+	m.RegisterTransform("mask", func(value interface{}, args ...string) (interface{}, error) {
+		s := value.(string)
+		if len(s) <= 4 {
+			return s, nil
+		}
+		return strings.Repeat("*", len(s)-4) + s[len(s)-4:], nil
+	})
+
+	type Source struct {
+		Email    string
+		Name     *string
+		Nickname *string
+		Bio      string
+		Card     string
+	}
+
+	type Target struct {
+		Email    string  `mapper:"transform:trim,lower"`
+		Name     string  `mapper:"transform:trim,title"`
+		Nickname *string `mapper:"transform:upper"`
+		Summary  string  `mapper:"fromField:Bio;transform:truncate(5),upper"`
+		Card     string  `mapper:"transform:mask"`
+	}
+
+	name := "  john doe "
+	source := Source{Email: " John.Doe@Example.com ", Name: &name, Bio: "Software engineer", Card: "4111111111111111"}
+	target := Target{}
+	err := m.Map(source, &target)
+	assert.Nil(t, err)
+
+	expected := Target{Email: "john.doe@example.com", Name: "John Doe", Summary: "SOFTW", Card: "************1111"}
+	assert.Equal(t, expected, target)
+}
+
+func Test_returnsErrWhenInvalidTransform(t *testing.T) {
+	type Source struct {
+		Name string
+		Age  int
+	}
+
+	type UnknownTransform struct {
+		Name string `mapper:"transform:reverse"`
+	}
+
+	err := Map(Source{Name: "John"}, &UnknownTransform{})
+	assert.ErrorIs(t, err, ErrUnknownTransform)
+
+	type InvalidType struct {
+		Age int `mapper:"transform:lower"`
+	}
+
+	err = Map(Source{Age: 30}, &InvalidType{})
+	assert.Error(t, err)
+
+	type InvalidArgs struct {
+		Name string `mapper:"transform:truncate(a)"`
+	}
+
+	err = Map(Source{Name: "John"}, &InvalidArgs{})
+	assert.Error(t, err)
+}
+
+type localeKey struct{}
+
+type Product struct {
+	Name  string
+	Price float64
+}
+
+func (p Product) LocalizedName(ctx context.Context) string {
+	if locale, ok := ctx.Value(localeKey{}).(string); ok && locale == "es" {
+		return p.Name + " (es)"
+	}
+	return p.Name
+}
+
+func (p *Product) FormattedPrice(currency string, decimals int) (string, error) {
+	if p.Price < 0 {
+		return "", errors.New("negative price")
+	}
+	return fmt.Sprintf("%v %.*f", currency, decimals, p.Price), nil
+}
+
+func (p Product) Discount(ctx context.Context, percent int) float64 {
+	return p.Price * float64(100-percent) / 100
+}
+
+func Test_mapStructWithFromMethodErrorsAndArguments(t *testing.T) {
+	type Target struct {
+		Price string `mapper:"fromMethod:FormattedPrice(USD, 2)"`
+	}
+
+	target := Target{}
+	err := Map(Product{Name: "Book", Price: 10.5}, &target)
+	assert.Nil(t, err)
+	assert.Equal(t, Target{Price: "USD 10.50"}, target)
+
+	// Errors returned by the method are propagated as a FieldError
+	err = Map(Product{Name: "Book", Price: -1}, &target)
+	assert.Error(t, err)
+	var fieldErr *FieldError
+	assert.True(t, errors.As(err, &fieldErr))
+	assert.Contains(t, err.Error(), "negative price")
+
+	type InvalidArguments struct {
+		Price string `mapper:"fromMethod:FormattedPrice(USD, two)"`
+	}
+
+	err = Map(Product{Name: "Book", Price: 10.5}, &InvalidArguments{})
+	assert.Error(t, err)
+
+	type MissingArguments struct {
+		Price string `mapper:"fromMethod:FormattedPrice"`
+	}
+
+	err = Map(Product{Name: "Book", Price: 10.5}, &MissingArguments{})
+	assert.Error(t, err)
+}
+
+func Test_mapContextWithFromMethodContext(t *testing.T) {
+	type Target struct {
+		Name  string  `mapper:"fromMethod:LocalizedName"`
+		Price float64 `mapper:"fromMethod:Discount(10)"`
+	}
+
+	ctx := context.WithValue(context.Background(), localeKey{}, "es")
+	target := Target{}
+	err := MapContext(ctx, Product{Name: "Book", Price: 10}, &target)
+	assert.Nil(t, err)
+	assert.Equal(t, Target{Name: "Book (es)", Price: 9}, target)
+
+	// Map uses a background context
+	err = Map(Product{Name: "Book", Price: 10}, &target)
+	assert.Nil(t, err)
+	assert.Equal(t, Target{Name: "Book", Price: 9}, target)
+
+	err = MapContext(nil, Product{}, &target)
+	assert.ErrorIs(t, err, ErrUnexpectedNil)
+}
+
+type OrderLineDTO struct {
+	Quantity int
+	Price    float64
+	Subtotal float64
+}
+
+func (l *OrderLineDTO) AfterMap(source interface{}) error {
+	if l.Quantity <= 0 {
+		return errors.New("quantity must be positive")
+	}
+	l.Subtotal = float64(l.Quantity) * l.Price
+	return nil
+}
+
+type OrderDTO struct {
+	ID     int
+	Lines  []OrderLineDTO
+	Total  float64
+	Source string
+}
+
+func (o *OrderDTO) BeforeMap(source interface{}) error {
+	o.Source = fmt.Sprintf("%T", source)
+	return nil
+}
+
+func (o *OrderDTO) AfterMap(source interface{}) error {
+	for _, line := range o.Lines {
+		o.Total += line.Subtotal
+	}
+	return nil
+}
+
+func Test_mapStructWithHooks(t *testing.T) {
+	type OrderLine struct {
+		Quantity int
+		Price    float64
+	}
+
+	type Order struct {
+		ID    int
+		Lines []OrderLine
+	}
+
+	source := Order{ID: 1, Lines: []OrderLine{{Quantity: 2, Price: 1.5}, {Quantity: 1, Price: 10}}}
+	target := OrderDTO{}
+	err := Map(source, &target)
+	assert.Nil(t, err)
+
+	expected := OrderDTO{
+		ID: 1,
+		Lines: []OrderLineDTO{
+			{Quantity: 2, Price: 1.5, Subtotal: 3},
+			{Quantity: 1, Price: 10, Subtotal: 10},
+		},
+		Total:  13,
+		Source: "mapper.Order",
+	}
+	assert.Equal(t, expected, target)
+
+	// Hook errors abort the mapping with the path of the field
+	source.Lines[1].Quantity = 0
+	err = Map(source, &OrderDTO{})
+	assert.Error(t, err)
+	var fieldErr *FieldError
+	assert.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, "Lines[1]", fieldErr.Path())
+	assert.Contains(t, err.Error(), "quantity must be positive")
+}
+
+type ValidatedDTO struct {
+	Name string
+}
+
+func (v ValidatedDTO) AfterMap(source interface{}) error {
+	if v.Name == "" {
+		return errors.New("name is required")
+	}
+	return nil
+}
+
+func Test_returnsErrWhenRootHookFails(t *testing.T) {
+	type Source struct {
+		Name string
+	}
+
+	err := Map(Source{}, &ValidatedDTO{})
+	assert.Error(t, err)
+	var fieldErr *FieldError
+	assert.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, "", fieldErr.Path())
+
+	err = Map(Source{Name: "John"}, &ValidatedDTO{})
+	assert.Nil(t, err)
+}
+
+func Test_returnsErrWithNestedFieldPath(t *testing.T) {
+	type Address struct {
+		Country string
+	}
+
+	type Person struct {
+		Addresses map[string]Address
+	}
+
+	type AddressDTO struct {
+		Country int
+	}
+
+	type PersonDTO struct {
+		Addresses map[string]AddressDTO
+	}
+
+	err := Map(Person{Addresses: map[string]Address{"home": {Country: "AR"}}}, &PersonDTO{})
+	assert.Error(t, err)
+	var fieldErr *FieldError
+	assert.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, "Addresses[home].Country", fieldErr.Path())
+}
+
+type Customer struct {
+	Name     string `mapper:"toMethod:Rename"`
+	Nickname string
+	email    string
+	tags     []string
+}
+
+func (c *Customer) Rename(name string) {
+	c.Name = strings.ToUpper(name)
+}
+
+func (c *Customer) SetEmail(email string) error {
+	if !strings.Contains(email, "@") {
+		return fmt.Errorf("invalid email: %v", email)
+	}
+	c.email = email
+	return nil
+}
+
+func (c *Customer) SetTags(tags []string) {
+	c.tags = tags
+}
+
+func (c *Customer) Email() string {
+	return c.email
+}
+
+func Test_mapStructWithSetters(t *testing.T) {
+	type CustomerDTO struct {
+		Name     string
+		Nickname string
+		Email    string
+		Tags     []string
+	}
+
+	source := CustomerDTO{Name: "John", Nickname: "jd", Email: "john@example.com", Tags: []string{"vip"}}
+	target := Customer{}
+	err := Map(source, &target)
+	assert.Nil(t, err)
+
+	expected := Customer{Name: "JOHN", Nickname: "jd", email: "john@example.com", tags: []string{"vip"}}
+	assert.Equal(t, expected, target)
+
+	// Setter errors are surfaced as a FieldError
+	source.Email = "invalid"
+	err = Map(source, &Customer{})
+	assert.Error(t, err)
+	var fieldErr *FieldError
+	assert.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, "email", fieldErr.Path())
+	assert.Contains(t, err.Error(), "invalid email")
+}
+
+func Test_returnsErrWhenSetterNotFound(t *testing.T) {
+	type Source struct {
+		Name string
+	}
+
+	type Target struct {
+		Name string `mapper:"toMethod:SetName"`
+	}
+
+	err := Map(Source{Name: "John"}, &Target{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "setter method SetName not found")
+}
+
+type EncapsulatedUser struct {
+	name  string
+	email string
+	age   int
+}
+
+func (u *EncapsulatedUser) GetName() string {
+	return u.name
+}
+
+func (u EncapsulatedUser) Email() string {
+	return u.email
+}
+
+func (u EncapsulatedUser) Age(ctx context.Context) (int, error) {
+	if u.age < 0 {
+		return 0, errors.New("invalid age")
+	}
+	return u.age, nil
+}
+
+func Test_mapStructWithGetterFallback(t *testing.T) {
+	type UserDTO struct {
+		Name  string
+		Email string
+		Age   int
+	}
+
+	source := EncapsulatedUser{name: "John", email: "john@example.com", age: 30}
+
+	// Disabled by default
+	target := UserDTO{}
+	err := Map(source, &target)
+	assert.Nil(t, err)
+	assert.Equal(t, UserDTO{}, target)
+
+	m := New(WithGetterFallback())
+	err = m.Map(source, &target)
+	assert.Nil(t, err)
+	assert.Equal(t, UserDTO{Name: "John", Email: "john@example.com", Age: 30}, target)
+
+	err = m.Map(&EncapsulatedUser{age: -1}, &target)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid age")
+}
+
+// ThirdPartyUser stands for a type we can't add tags to
+type ThirdPartyUser struct {
+	ID        int
+	FirstName string
+	LastName  string
+	Email     string
+	Password  string
+	Created   time.Time
+}
+
+func (u ThirdPartyUser) GetFullName() string {
+	return u.FirstName + " " + u.LastName
+}
+
+func Test_mapStructWithFluentConfig(t *testing.T) {
+	type UserDTO struct {
+		ID       int
+		FullName string
+		Contact  string
+		Password string
+		Created  string `mapper:"layout:2006-01-02T15:04:05Z07:00"`
+	}
+
+	created := time.Date(2021, 10, 5, 12, 0, 0, 0, time.UTC)
+	source := ThirdPartyUser{ID: 1, FirstName: "John", LastName: "Doe", Email: " John@Example.com", Password: "secret", Created: created}
+
+	// Without configuration, only fields with the same name (and tags) are used
+	m := New()
+	target := UserDTO{}
+	err := m.Map(source, &target)
+	assert.Nil(t, err)
+	assert.Equal(t, UserDTO{ID: 1, Password: "secret", Created: "2021-10-05T12:00:00Z"}, target)
+
+	For[ThirdPartyUser, UserDTO](m.Config()).
+		Field("FullName", FromMethod("GetFullName")).
+		Field("Contact", FromField("Email"), Transform("trim", "lower")).
+		Field("Created", Layout("2006-01-02")).
+		Ignore("Password")
+
+	target = UserDTO{}
+	err = m.Map(&source, &target)
+	assert.Nil(t, err)
+
+	// The configuration takes precedence over tags
+	expected := UserDTO{ID: 1, FullName: "John Doe", Contact: "john@example.com", Created: "2021-10-05"}
+	assert.Equal(t, expected, target)
+
+	assert.Panics(t, func() {
+		For[ThirdPartyUser, UserDTO](NewConfig()).Ignore("Passwd")
+	})
+}
+
+func Test_mapWithIsolatedMappers(t *testing.T) {
+	type Source struct {
+		Name     string
+		Status   Status
+		Password string
+	}
+	type Target struct {
+		Name     string
+		Status   string
+		Password string
+	}
+
+	upper := New(WithConverters(map[string]TypeConverterFn{
+		"string": func(v interface{}) interface{} { return strings.ToUpper(fmt.Sprintf("%v", v)) },
+	}))
+	upper.RegisterEnum(map[Status]string{StatusActive: "on", StatusBlocked: "off"})
+	For[Source, Target](upper.Config()).Ignore("Password")
+
+	plain := New()
+	source := Source{Name: "john", Status: StatusBlocked, Password: "secret"}
+
+	target := Target{}
+	err := upper.Map(source, &target)
+	assert.Nil(t, err)
+	assert.Equal(t, Target{Name: "JOHN", Status: "off"}, target)
+
+	// Converters, enums and configuration of other mappers are not shared
+	target = Target{}
+	err = plain.Map(source, &target)
+	assert.Nil(t, err)
+	assert.Equal(t, Target{Name: "john", Status: "1", Password: "secret"}, target)
+}
+
+func Test_mapWithStrictMapper(t *testing.T) {
+	type Source struct {
+		Name string
+	}
+	type Target struct {
+		Name  string
+		Email string
+	}
+
+	m := New(WithStrict())
+	target := Target{}
+	err := m.Map(Source{Name: "john"}, &target)
+	assert.ErrorIs(t, err, ErrMissingSourceField)
+
+	var fieldErr *FieldError
+	assert.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, "Email", fieldErr.Path())
+
+	// Ignored fields are not required
+	For[Source, Target](m.Config()).Ignore("Email")
+	err = m.Map(Source{Name: "john"}, &target)
+	assert.Nil(t, err)
+	assert.Equal(t, Target{Name: "john"}, target)
+}
+
+func Test_mapWithMergeMapper(t *testing.T) {
+	type Address struct {
+		City    string
+		Country string
+	}
+	type User struct {
+		Name    string
+		Email   string
+		Age     int
+		Address Address
+	}
+
+	target := User{Name: "John", Email: "john@example.com", Age: 30, Address: Address{City: "Rosario", Country: "AR"}}
+	patch := User{Email: "john@doe.com", Address: Address{City: "Córdoba"}}
+
+	err := New(WithMerge()).Map(patch, &target)
+	assert.Nil(t, err)
+	assert.Equal(t, User{Name: "John", Email: "john@doe.com", Age: 30, Address: Address{City: "Córdoba", Country: "AR"}}, target)
+}
+
+func Test_mapConcurrentlyWithMapper(t *testing.T) {
+	m := New(WithGetterFallback())
+	m.RegisterTransform("shout", stringTransform(func(s string) string { return s + "!" }))
+
+	type Target struct {
+		Name string `mapper:"transform:shout"`
+		Age  int
+	}
+
+	errs := make(chan error, 50)
+	for i := 0; i < 50; i++ {
+		go func(i int) {
+			target := Target{}
+			err := m.Map(&EncapsulatedUser{name: "John", age: i}, &target)
+			if err == nil && (target.Name != "John!" || target.Age != i) {
+				err = fmt.Errorf("unexpected target %+v", target)
+			}
+			errs <- err
+		}(i)
+	}
+	for i := 0; i < 50; i++ {
+		assert.Nil(t, <-errs)
+	}
+}
+
+func Test_mapWithNamingStrategies(t *testing.T) {
+	type Source struct {
+		UserName   string
+		User_Age   int
+		Created_At string
+		UserId     int
+		Mail       string
+	}
+	type Target struct {
+		Name      string
+		UserAge   int
+		CreatedAt string
+		UserID    int
+		Email     string
+	}
+
+	m := New(WithNaming(
+		CaseInsensitive(),
+		SnakeCase(),
+		StripPrefix("User"),
+		Candidates(func(field string) []string {
+			if field == "Email" {
+				return []string{"Mail", "EmailAddress"}
+			}
+			return nil
+		}),
+	))
+
+	source := Source{UserName: "John", User_Age: 30, Created_At: "2021-10-05", UserId: 1, Mail: "john@example.com"}
+	target := Target{}
+	err := m.Map(source, &target)
+	assert.Nil(t, err)
+	assert.Equal(t, Target{Name: "John", UserAge: 30, CreatedAt: "2021-10-05", UserID: 1, Email: "john@example.com"}, target)
+
+	// Without naming strategies only identical names are matched
+	target = Target{}
+	err = Map(source, &target)
+	assert.Nil(t, err)
+	assert.Equal(t, Target{}, target)
+}
+
+func Test_returnsErrWhenNamingIsAmbiguous(t *testing.T) {
+	type Source struct {
+		UserName     string
+		CustomerName string
+	}
+	type Target struct {
+		Name string
+	}
+
+	m := New(WithNaming(StripPrefix("User", "Customer")))
+	err := m.Map(Source{UserName: "John", CustomerName: "Doe"}, &Target{})
+	assert.ErrorIs(t, err, ErrAmbiguousField)
+	assert.Contains(t, err.Error(), "UserName, CustomerName")
+
+	var fieldErr *FieldError
+	assert.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, "Name", fieldErr.Path())
+}
+
+func Test_mapWithMatchTags(t *testing.T) {
+	type UserEntity struct {
+		ID        int    `gorm:"primaryKey"`
+		FullName  string `gorm:"column:user_name;not null"`
+		EmailAddr string `gorm:"column:email"`
+		CreatedAt string
+		Password  string `gorm:"-"`
+	}
+	type UserDTO struct {
+		Key       int    `json:"id"`
+		Name      string `json:"user_name"`
+		Email     string `json:"email,omitempty"`
+		Created   string `json:"created_at"`
+		Password  string `json:"password"`
+		Internal  string `json:"-"`
+		Untouched string
+	}
+
+	m := New(WithMatchTags("gorm", "json"))
+	source := UserEntity{ID: 1, FullName: "John", EmailAddr: "john@example.com", CreatedAt: "2021-10-05", Password: "secret"}
+	target := UserDTO{Internal: "keep"}
+	err := m.Map(source, &target)
+	assert.Nil(t, err)
+	assert.Equal(t, UserDTO{Key: 1, Name: "John", Email: "john@example.com", Created: "2021-10-05", Internal: "keep"}, target)
+}
+
+func Test_mapWithMatchTag(t *testing.T) {
+	type Source struct {
+		First string `json:"first_name"`
+		Last  string `json:"last_name,omitempty"`
+		Age   int
+		Token string `json:"-"`
+	}
+	type Target struct {
+		Name    string `json:"first_name"`
+		Surname string `json:"last_name"`
+		Age     int    `json:",omitempty"`
+		Token   string
+	}
+
+	m := New(WithMatchTag("json"))
+	target := Target{}
+	err := m.Map(Source{First: "John", Last: "Doe", Age: 30, Token: "secret"}, &target)
+	assert.Nil(t, err)
+	assert.Equal(t, Target{Name: "John", Surname: "Doe", Age: 30}, target)
+}
+
+func Test_mapWithMatchTagFromUntaggedSource(t *testing.T) {
+	type Entity struct {
+		ID       int
+		UserName string
+	}
+	type DTO struct {
+		ID       int    `json:"id"`
+		UserName string `json:"user_name"`
+	}
+
+	// Source fields without the tag are matched by the Go name of the target field
+	m := New(WithMatchTag("json"), WithStrict())
+	target := DTO{}
+	err := m.Map(Entity{ID: 1, UserName: "john"}, &target)
+	assert.Nil(t, err)
+	assert.Equal(t, DTO{ID: 1, UserName: "john"}, target)
+	assert.Nil(t, m.Validate(Pair[Entity, DTO]()))
+}
+
+func Test_mapStructWithToFieldBothWays(t *testing.T) {
+	type Account struct {
+		ID       int
+		Email    string
+		Nickname string `mapper:"toField:Alias"`
+	}
+	type AccountDTO struct {
+		Key     int    `mapper:"toField:ID"`
+		Contact string `mapper:"toField:Email"`
+		Alias   string `mapper:"toField:Nickname"`
+	}
+
+	account := Account{ID: 1, Email: "john@example.com", Nickname: "johnny"}
+	dto := AccountDTO{}
+	err := Map(account, &dto)
+	assert.Nil(t, err)
+	assert.Equal(t, AccountDTO{Key: 1, Contact: "john@example.com", Alias: "johnny"}, dto)
+
+	// The same tags are used in reverse
+	reversed := Account{}
+	err = Map(dto, &reversed)
+	assert.Nil(t, err)
+	assert.Equal(t, account, reversed)
+}
+
+func Test_mapStructWithFromFieldIntoSameType(t *testing.T) {
+	type DTO struct {
+		Name    string
+		Display string `mapper:"fromField:Name"`
+	}
+	type Student struct {
+		Name         string
+		StudentScore int `mapper:"fromField:Score"`
+	}
+	type Report struct {
+		Name  string
+		Score int
+	}
+
+	// Source fields named like the target fields are used, regardless of the source tags
+	out := DTO{}
+	err := Map(DTO{Name: "n", Display: "d"}, &out)
+	assert.Nil(t, err)
+	assert.Equal(t, DTO{Name: "n", Display: "n"}, out)
+
+	// Source fromField tags are not reversed implicitly
+	report := Report{}
+	err = Map(Student{Name: "John", StudentScore: 9}, &report)
+	assert.Nil(t, err)
+	assert.Equal(t, Report{Name: "John"}, report)
+}
+
+func Test_returnsErrWhenToFieldIsAmbiguous(t *testing.T) {
+	type Source struct {
+		First  string `mapper:"toField:Name"`
+		Second string `mapper:"toField:Name"`
+	}
+	type Target struct {
+		Name string
+	}
+
+	err := Map(Source{First: "John", Second: "Doe"}, &Target{})
+	assert.ErrorIs(t, err, ErrAmbiguousField)
+}
+
+type ReversibleAddress struct {
+	Street string
+	City   string
+}
+
+type ReversibleUser struct {
+	ID        int
+	FirstName string
+	LastName  string
+	Email     string
+	Password  string
+	Birthday  time.Time
+	Address   ReversibleAddress
+	Billing   *ReversibleAddress
+}
+
+func (u ReversibleUser) GetFullName() string {
+	return u.FirstName + " " + u.LastName
+}
+
+func (u ReversibleUser) Initials() string {
+	return u.FirstName[:1] + u.LastName[:1]
+}
+
+func (u *ReversibleUser) SetFullName(name string) {
+	parts := strings.SplitN(name, " ", 2)
+	u.FirstName, u.LastName = parts[0], parts[1]
+}
+
+func Test_mapWithReverseMap(t *testing.T) {
+	type UserDTO struct {
+		Key         int
+		Contact     string
+		FullName    string
+		Initials    string
+		Birthday    string
+		Password    string
+		City        string
+		BillingCity string
+	}
+
+	cfg := NewConfig()
+	_, err := For[ReversibleUser, UserDTO](cfg).
+		Field("Key", FromField("ID")).
+		Field("Contact", FromField("Email"), Transform("lower")).
+		Field("FullName", FromMethod("GetFullName"), ReverseSetter("SetFullName")).
+		Field("Initials", FromMethod("Initials")).
+		Field("Birthday", Layout("2006-01-02")).
+		Field("City", FromField("Address.City")).
+		Field("BillingCity", FromField("Billing.City")).
+		Ignore("Password").
+		ReverseMap()
+	assert.ErrorIs(t, err, ErrIrreversibleField)
+	assert.Contains(t, err.Error(), "Initials (fromMethod:Initials)")
+
+	// Explicitly ignored fromMethod fields can be reversed
+	reverse, err := For[ReversibleUser, UserDTO](cfg).Field("Initials", FromMethod("Initials"), ReverseIgnore()).ReverseMap()
+	assert.Nil(t, err)
+	assert.Equal(t, reflect.TypeOf(UserDTO{}), reverse.source)
+
+	m := New(WithConfig(cfg))
+	user := ReversibleUser{
+		ID:        1,
+		FirstName: "John",
+		LastName:  "Doe",
+		Email:     "john@example.com",
+		Password:  "secret",
+		Birthday:  time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC),
+		Address:   ReversibleAddress{Street: "Main St", City: "Rosario"},
+		Billing:   &ReversibleAddress{City: "Córdoba"},
+	}
+	dto := UserDTO{}
+	err = m.Map(user, &dto)
+	assert.Nil(t, err)
+	assert.Equal(t, UserDTO{Key: 1, Contact: "john@example.com", FullName: "John Doe", Initials: "JD", Birthday: "1990-05-17", City: "Rosario", BillingCity: "Córdoba"}, dto)
+
+	dto.Password = "ignored"
+	reversed := ReversibleUser{}
+	err = m.Map(dto, &reversed)
+	assert.Nil(t, err)
+	user.Password = ""
+	user.Address.Street = ""
+	assert.Equal(t, user, reversed)
+
+	// Nil pointers on the way of a nested field are only allocated if there's something to set
+	reversed = ReversibleUser{}
+	err = m.Map(UserDTO{Key: 2, FullName: "Jane Roe", Birthday: "1990-05-17"}, &reversed)
+	assert.Nil(t, err)
+	assert.Nil(t, reversed.Billing)
+}
+
+func Test_validateTypePairs(t *testing.T) {
+	type Person struct {
+		Name     string
+		Age      int
+		Tags     []string
+		Address  ReversibleAddress
+		Birthday time.Time
+	}
+	type PersonDTO struct {
+		FirstName string `mapper:"fromField:Name"`
+		Years     int    `mapper:"fromField:Age"`
+		Tags      []string
+		Address   ReversibleAddress
+		Birthday  string `mapper:"layout:2006-01-02"`
+	}
+
+	m := New()
+	assert.Nil(t, m.Validate(Pair[Person, PersonDTO]()))
+	assert.Nil(t, m.Validate(Pair[*ReversibleUser, ReversibleUser]()))
+
+	type BrokenDTO struct {
+		FirstName string `mapper:"fromFeild:Name"`
+		FullName  string `mapper:"fromMethod:GetFullNmae"`
+		Initials  string `mapper:"fromMethod:Initials(short)"`
+		City      string `mapper:"fromField:Address.Town"`
+		Email     string `mapper:"transform:trim,lowr"`
+		Age       []int
+		Address   int
+		Unmapped  string
+	}
+
+	err := m.Validate(Pair[ReversibleUser, BrokenDTO]())
+	var validationErr *ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+	expected := []string{
+		`mapper.ReversibleUser -> mapper.BrokenDTO: FirstName: unknown tag option "fromFeild"`,
+		`mapper.ReversibleUser -> mapper.BrokenDTO: FullName: source method GetFullNmae not found in mapper.ReversibleUser`,
+		`mapper.ReversibleUser -> mapper.BrokenDTO: Initials: method Initials receives 0 argument(s), 1 given`,
+		`mapper.ReversibleUser -> mapper.BrokenDTO: City: source field Address.Town not found in mapper.ReversibleUser`,
+		`mapper.ReversibleUser -> mapper.BrokenDTO: Email: unknown transform "lowr"`,
+		`mapper.ReversibleUser -> mapper.BrokenDTO: Age: unmapped target field, no source field found in mapper.ReversibleUser`,
+		`mapper.ReversibleUser -> mapper.BrokenDTO: Address: cannot map mapper.ReversibleAddress into int without a converter`,
+		`mapper.ReversibleUser -> mapper.BrokenDTO: Unmapped: unmapped target field, no source field found in mapper.ReversibleUser`,
+	}
+	assert.Equal(t, expected, validationErr.Problems)
+
+	// Configured pairs are validated too, and ignored fields are not reported
+	For[ReversibleUser, BrokenDTO](m.Config()).
+		Field("FirstName", FromField("FirstName")).
+		Field("FullName", FromMethod("GetFullName")).
+		Field("Initials", FromMethod("Initials")).
+		Field("City", FromField("Address.City")).
+		Field("Email", Transform("trim", "lower")).
+		Ignore("Age", "Address", "Unmapped")
+	assert.Nil(t, m.Validate())
+}
+
+func Test_mapWithTrace(t *testing.T) {
+	type Source struct {
+		ID        int
+		Email     string
+		FirstName string
+		LastName  string
+		Created   time.Time
+		Address   *ReversibleAddress
+		Billing   *ReversibleAddress
+		nickname  string
+	}
+	type Target struct {
+		ID       int
+		Contact  string `mapper:"fromField:Email"`
+		Created  time.Time
+		Address  *ReversibleAddress
+		Billing  *ReversibleAddress
+		Nickname string
+		Phone    string
+		Password string
+	}
+
+	m := New()
+	For[Source, Target](m.Config()).Ignore("Password")
+
+	created := time.Date(2021, 10, 5, 12, 0, 0, 0, time.UTC)
+	source := Source{ID: 1, Email: "john@example.com", Created: created, Address: &ReversibleAddress{City: "Rosario"}, nickname: "johnny"}
+	target := Target{}
+	plan, err := m.MapWithTrace(source, &target)
+	assert.Nil(t, err)
+	assert.Equal(t, "Rosario", target.Address.City)
+
+	expected := Plan{
+		Source: "mapper.Source",
+		Target: "*mapper.Target",
+		Steps: []PlanStep{
+			{Path: "ID", Resolution: ResolutionField, Source: "ID"},
+			{Path: "Contact", Resolution: ResolutionFromField, Source: "Email"},
+			{Path: "Created", Resolution: ResolutionField, Source: "Created"},
+			{Path: "Created", Resolution: ResolutionConverter, Source: "time.Time"},
+			{Path: "Address", Resolution: ResolutionField, Source: "Address"},
+			{Path: "Address.Street", Resolution: ResolutionField, Source: "Street"},
+			{Path: "Address.City", Resolution: ResolutionField, Source: "City"},
+			{Path: "Billing", Resolution: SkippedNil, Source: "Billing"},
+			{Path: "Nickname", Resolution: SkippedUnexported, Source: "Nickname"},
+			{Path: "Phone", Resolution: SkippedMissing, Source: "Phone"},
+			{Path: "Password", Resolution: SkippedIgnored},
+		},
+	}
+	assert.Equal(t, expected, plan)
+	table := `mapper.Source -> *mapper.Target
+PATH            RESOLUTION           SOURCE
+ID              field                ID
+Contact         fromField            Email
+Created         field                Created
+Created         converter            time.Time
+Address         field                Address
+Address.Street  field                Street
+Address.City    field                City
+Billing         skipped: nil         Billing
+Nickname        skipped: unexported  Nickname
+Phone           skipped: missing     Phone
+Password        skipped: ignored
+`
+	assert.Equal(t, table, plan.String())
+	assert.Contains(t, plan.JSON(), `"resolution": "skipped: unexported"`)
+
+	// Explain doesn't modify the target
+	target = Target{}
+	explained := m.Explain(source, &target)
+	assert.Equal(t, expected, explained)
+	assert.Equal(t, Target{}, target)
+}
+
+func Test_mapWithLogger(t *testing.T) {
+	type Source struct {
+		Name    string
+		Address ReversibleAddress
+	}
+	type Target struct {
+		Name     string
+		Address  string
+		Phone    string
+		Password string
+	}
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	m := New(WithLogger(SlogLogger(logger)))
+	For[Source, Target](m.Config()).Ignore("Password")
+
+	target := Target{}
+	err := m.Map(Source{Name: "John", Address: ReversibleAddress{City: "Rosario"}}, &target)
+	assert.Nil(t, err)
+
+	var events []map[string]interface{}
+	decoder := json.NewDecoder(&buf)
+	for decoder.More() {
+		var event map[string]interface{}
+		assert.Nil(t, decoder.Decode(&event))
+		delete(event, "time")
+		events = append(events, event)
+	}
+	assert.Equal(t, []map[string]interface{}{
+		{"level": "DEBUG", "msg": "converter fallback", "path": "Address", "source_type": "mapper.ReversibleAddress", "target_type": "string", "reason": "no converter for the type, formatted with fmt"},
+		{"level": "DEBUG", "msg": "skipped field", "path": "Phone", "source_type": "mapper.Source", "target_type": "string", "reason": "missing"},
+		{"level": "DEBUG", "msg": "skipped field", "path": "Password", "source_type": "mapper.Source", "target_type": "string", "reason": "ignored"},
+	}, events)
+
+	// The standard logger only receives the events of the given level or above
+	var std bytes.Buffer
+	m = New(WithLogger(StdLogger(log.New(&std, "", 0), slog.LevelDebug)))
+	err = m.Map(Source{Name: "John"}, &Target{})
+	assert.Nil(t, err)
+	assert.Contains(t, std.String(), "DEBUG skipped field path=Phone source_type=mapper.Source target_type=string reason=missing\n")
+
+	std.Reset()
+	m = New(WithLogger(StdLogger(log.New(&std, "", 0), slog.LevelWarn)))
+	err = m.Map(Source{Name: "John"}, &Target{})
+	assert.Nil(t, err)
+	assert.Empty(t, std.String())
+}
+
+func Test_mapWithInstrumentation(t *testing.T) {
+	type Source struct {
+		Addresses []ReversibleAddress
+	}
+	type Target struct {
+		Addresses []ReversibleAddress
+		Contact   int `mapper:"fromField:Addresses"`
+	}
+
+	collector := NewCollector()
+	m := New(WithInstrumentation(collector), WithNestedInstrumentation())
+
+	source := Source{Addresses: []ReversibleAddress{{City: "Rosario"}, {City: "Córdoba"}}}
+	err := m.Map(source, &Target{})
+	assert.NotNil(t, err)
+	err = m.Map([]ReversibleAddress{{City: "Rosario"}}, &[]ReversibleAddress{})
+	assert.Nil(t, err)
+
+	summaries := collector.Summary()
+	counts := map[TypePair]PairSummary{}
+	for _, s := range summaries {
+		counts[s.Pair] = s
+	}
+	assert.Len(t, summaries, 3)
+	assert.Equal(t, 1, counts[Pair[Source, Target]()].Count)
+	assert.Equal(t, 1, counts[Pair[Source, Target]()].Errors)
+	assert.Equal(t, 2, counts[Pair[Source, Target]()].Elements)
+	assert.False(t, counts[Pair[Source, Target]()].Nested)
+	assert.Equal(t, 1, counts[Pair[[]ReversibleAddress, []ReversibleAddress]()].Elements)
+	assert.Equal(t, 3, counts[Pair[ReversibleAddress, ReversibleAddress]()].Count)
+	assert.True(t, counts[Pair[ReversibleAddress, ReversibleAddress]()].Nested)
+
+	var b strings.Builder
+	_, err = collector.WriteTo(&b)
+	assert.Nil(t, err)
+	lines := strings.Split(b.String(), "\n")
+	assert.Equal(t, []string{"PAIR", "NESTED", "COUNT", "ERRORS", "TOTAL", "MEAN", "MAX", "ELEMENTS", "ALLOCS"}, strings.Fields(lines[0]))
+	assert.Len(t, lines, 5)
+	for _, line := range lines[1:4] {
+		if strings.HasPrefix(line, "mapper.Source") {
+			assert.Equal(t, []string{"mapper.Source", "->", "mapper.Target", "false", "1", "1"}, strings.Fields(line)[:6])
+		}
+	}
+
+	collector.Reset()
+	assert.Empty(t, collector.Summary())
+}
+
+func Test_mapSliceInParallel(t *testing.T) {
+	type Source struct {
+		ID   int
+		Name string
+		Tags []string
+	}
+	type Target struct {
+		ID   int
+		Name string
+		Tags []string
+	}
+
+	source := make([]Source, 10000)
+	for i := range source {
+		source[i] = Source{ID: i, Name: fmt.Sprintf("item %d", i), Tags: []string{"a", "b"}}
+	}
+
+	m := New(WithParallelism(ParallelPolicy{Workers: 4, Threshold: 100}))
+	var target []Target
+	err := m.Map(source, &target)
+	assert.Nil(t, err)
+	assert.Len(t, target, len(source))
+	for i, item := range target {
+		assert.Equal(t, Target{ID: i, Name: fmt.Sprintf("item %d", i), Tags: []string{"a", "b"}}, item)
+	}
+
+	// Small slices are mapped sequentially
+	target = nil
+	err = m.Map(source[:10], &target)
+	assert.Nil(t, err)
+	assert.Len(t, target, 10)
+}
+
+func Test_mapSliceInParallelErrors(t *testing.T) {
+	type Source struct {
+		Value interface{}
+	}
+	type Target struct {
+		Value int
+	}
+
+	source := make([]Source, 1000)
+	for i := range source {
+		source[i] = Source{Value: i}
+	}
+	source[700].Value = "invalid"
+	source[3].Value = "invalid"
+
+	// The error of the first element failing is returned, however the elements are scheduled
+	m := New(WithParallelism(ParallelPolicy{Workers: 8, Threshold: 100}))
+	for i := 0; i < 10; i++ {
+		var target []Target
+		err := m.Map(source, &target)
+		var fieldErr *FieldError
+		assert.True(t, errors.As(err, &fieldErr))
+		assert.Equal(t, "[3].Value", fieldErr.Path())
+	}
+
+	m = New(WithParallelism(ParallelPolicy{Workers: 8, Threshold: 100, AllErrors: true}))
+	var target []Target
+	err := m.Map(source, &target)
+	assert.NotNil(t, err)
+	errs := err.(interface{ Unwrap() []error }).Unwrap()
+	assert.Len(t, errs, 2)
+	assert.Contains(t, errs[0].Error(), "[3].Value")
+	assert.Contains(t, errs[1].Error(), "[700].Value")
+
+	// Sequentially mapped slices return all the errors too
+	err = m.Map(source[:10], &target)
+	assert.NotNil(t, err)
+	assert.Len(t, err.(interface{ Unwrap() []error }).Unwrap(), 1)
+
+	// Canceled contexts stop the workers
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = m.MapContext(ctx, source, &target)
+	assert.True(t, errors.Is(err, context.Canceled))
+}
+
+func Test_mapContextCancellation(t *testing.T) {
+	type Stamp struct {
+		Value string
+	}
+	type Source struct {
+		ID    int
+		Stamp string
+		Name  string
+	}
+	type Target struct {
+		ID    int
+		Stamp Stamp
+		Name  string
+	}
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), localeKey{}, "es"))
+	defer cancel()
+
+	calls := 0
+	m := New(WithContextConverters(map[string]ContextConverterFn{
+		"mapper.Stamp": func(ctx context.Context, value interface{}) interface{} {
+			calls++
+			if calls == 3 {
+				cancel()
+			}
+			return Stamp{Value: fmt.Sprintf("%v-%v", ctx.Value(localeKey{}), value)}
+		},
+	}))
+
+	source := []Source{{ID: 1, Stamp: "a"}, {ID: 2, Stamp: "b"}, {ID: 3, Stamp: "c"}, {ID: 4, Stamp: "d"}}
+	var target []Target
+	err := m.MapContext(ctx, source, &target)
+	assert.True(t, errors.Is(err, context.Canceled))
+	var fieldErr *FieldError
+	assert.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, "[2].Name", fieldErr.Path())
+	assert.Equal(t, 3, calls)
+
+	// The context is passed to the converters
+	target = nil
+	err = m.MapContext(context.WithValue(context.Background(), localeKey{}, "en"), source[:1], &target)
+	assert.Nil(t, err)
+	assert.Equal(t, []Target{{ID: 1, Stamp: Stamp{Value: "en-a"}}}, target)
+
+	// Canceled contexts stop the mapping of struct fields and map entries too
+	canceled, cancelNow := context.WithCancel(context.Background())
+	cancelNow()
+	err = m.MapContext(canceled, source[0], &Target{})
+	assert.True(t, errors.Is(err, context.Canceled))
+	err = m.MapContext(canceled, map[string]int{"a": 1}, &map[string]int64{})
+	assert.True(t, errors.Is(err, context.Canceled))
+}
+
+func Test_mapWithPointerTypeConverter(t *testing.T) {
+	type Source struct {
+		Created string
+		Updated string
+	}
+	type Target struct {
+		Created *time.Time
+		Updated *time.Time
+	}
+
+	epoch := time.Unix(0, 0)
+	converters := map[string]TypeConverterFn{
+		"*time.Time": func(value interface{}) interface{} {
+			if value == "pointer" {
+				return &epoch
+			}
+			return epoch
+		},
+	}
+
+	// Converters for pointer types may return the element value, or a pointer to it
+	target := Target{}
+	err := MapWithConverters(Source{Created: "value", Updated: "pointer"}, &target, converters)
+	assert.Nil(t, err)
+	assert.Equal(t, epoch, *target.Created)
+	assert.Equal(t, epoch, *target.Updated)
+
+	// Values of other types are reported
+	err = MapWithConverters(Source{Created: "value"}, &target, map[string]TypeConverterFn{
+		"*time.Time": func(value interface{}) interface{} {
+			return "not a time"
+		},
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "the converter for *time.Time returned a string")
+}
@@ -0,0 +1,117 @@
+package mapper
+
+import (
+	"errors"
+	"reflect"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// ParallelPolicy defines how the elements of large slices are mapped in parallel (see WithParallelism)
+type ParallelPolicy struct {
+	// Workers is the number of goroutines mapping the elements of a slice, GOMAXPROCS if zero
+	Workers int
+	// Threshold is the minimum number of elements of a slice to be mapped in parallel
+	Threshold int
+	// AllErrors returns the errors of all the elements of a slice (joined, in order) instead of the error of the
+	// first element failing. It applies to the slices mapped sequentially too.
+	AllErrors bool
+}
+
+// WithParallelism maps the elements of the slices with at least policy.Threshold elements in parallel, e.g:
+//
+//	mapper.New(mapper.WithParallelism(mapper.ParallelPolicy{Workers: 8, Threshold: 1000}))
+//
+// Elements keep their order, and the error returned is the same as when mapping them sequentially: the error of
+// the first element failing (by index), or the errors of all of them with AllErrors. Slices nested in the elements
+// are mapped sequentially, and so are all the slices when tracing (see MapWithTrace).
+// Converters, hooks and methods called while mapping the elements must be safe for concurrent use.
+func WithParallelism(policy ParallelPolicy) Option {
+	return func(m *Mapper) {
+		if policy.Workers <= 0 {
+			policy.Workers = runtime.GOMAXPROCS(0)
+		}
+		m.parallel = &policy
+	}
+}
+
+// fork returns a copy of the state for a goroutine mapping the elements of a slice
+func (s *mappingState) fork() *mappingState {
+	fork := *s
+	fork.path = append([]string(nil), s.path...)
+	fork.elements = 0
+	fork.forked = true
+	return &fork
+}
+
+// parallelSlice reports whether the elements of a slice of the given length are mapped in parallel
+func (s *mappingState) parallelSlice(length int) bool {
+	policy := s.mapper.parallel
+	return policy != nil && policy.Workers > 1 && length > 1 && length >= policy.Threshold && !s.forked && s.plan == nil
+}
+
+// mapSliceItemsInParallel maps the elements of the source slice into the target slice with the workers of the
+// parallel policy. Elements are handed out by index, so when a worker fails, the elements before the failing one
+// are still mapped to find the first error, and the ones after it are skipped.
+func mapSliceItemsInParallel(sourceValue, targetSlice reflect.Value, state *mappingState) error {
+	policy := state.mapper.parallel
+	numItems := sourceValue.Len()
+	workers := policy.Workers
+	if workers > numItems {
+		workers = numItems
+	}
+
+	errs := make([]error, numItems)
+	var next int64 = -1
+	var firstFailed int64 = int64(numItems)
+	var canceled int32
+	forks := make([]*mappingState, workers)
+
+	var wg sync.WaitGroup
+	for w := range forks {
+		forks[w] = state.fork()
+		wg.Add(1)
+		go func(fork *mappingState) {
+			defer wg.Done()
+			for {
+				i := int(atomic.AddInt64(&next, 1))
+				if i >= numItems {
+					return
+				}
+				if fork.ctx.Err() != nil {
+					atomic.StoreInt32(&canceled, 1)
+					return
+				}
+				if !policy.AllErrors && int64(i) > atomic.LoadInt64(&firstFailed) {
+					return
+				}
+
+				if err := mapSliceItem(sourceValue, targetSlice, i, fork); err != nil {
+					errs[i] = err
+					for failed := atomic.LoadInt64(&firstFailed); int64(i) < failed; failed = atomic.LoadInt64(&firstFailed) {
+						if atomic.CompareAndSwapInt64(&firstFailed, failed, int64(i)) {
+							break
+						}
+					}
+				}
+			}
+		}(forks[w])
+	}
+	wg.Wait()
+
+	for _, fork := range forks {
+		state.elements += fork.elements
+	}
+
+	if atomic.LoadInt32(&canceled) == 1 {
+		return state.fieldError("mapping canceled", state.ctx.Err())
+	}
+	if policy.AllErrors {
+		return errors.Join(errs...)
+	}
+	if failed := atomic.LoadInt64(&firstFailed); failed < int64(numItems) {
+		return errs[failed]
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package mapper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Resolution describes how the value of a target field was resolved, or why it was skipped
+type Resolution string
+
+const (
+	// ResolutionField the source field with the same name (or tag name) was used
+	ResolutionField Resolution = "field"
+	// ResolutionFromField the source field named by the `fromField` option was used
+	ResolutionFromField Resolution = "fromField"
+	// ResolutionToField the source field named by the `toField` option of the target field was used
+	ResolutionToField Resolution = "toField"
+	// ResolutionSourceTag the source field tagged with `toField` naming the target field was used
+	ResolutionSourceTag Resolution = "source tag"
+	// ResolutionFromMethod the result of the source method named by the `fromMethod` option was used
+	ResolutionFromMethod Resolution = "fromMethod"
+	// ResolutionNaming the source field found by a naming strategy was used
+	ResolutionNaming Resolution = "naming"
+	// ResolutionGetter the result of a `Get<Field>` or `<Field>` getter of the source was used
+	ResolutionGetter Resolution = "getter"
+	// ResolutionConverter the value was converted by the converter registered for the target type
+	ResolutionConverter Resolution = "converter"
+	// ResolutionEnum the value was converted by a registered enum table
+	ResolutionEnum Resolution = "enum"
+	// SkippedIgnored the target field is ignored by the configuration or its tags
+	SkippedIgnored Resolution = "skipped: ignored"
+	// SkippedMissing there's no source field for the target field
+	SkippedMissing Resolution = "skipped: missing"
+	// SkippedUnexported the source field is not exported
+	SkippedUnexported Resolution = "skipped: unexported"
+	// SkippedZero the source value is zero, and the Mapper merges values
+	SkippedZero Resolution = "skipped: zero"
+	// SkippedNil the source value is nil (or a zero pointer), so there's nothing to set
+	SkippedNil Resolution = "skipped: nil"
+)
+
+// PlanStep records how the value of a target path was resolved
+type PlanStep struct {
+	Path       string     `json:"path"`
+	Resolution Resolution `json:"resolution"`
+	// Source describes the source of the value, e.g. the source field or method, or the converter used
+	Source string `json:"source,omitempty"`
+}
+
+// Plan records how each target path of a mapping was resolved. It prints as a table with String,
+// and as JSON with encoding/json, so it can be pasted into bug reports.
+type Plan struct {
+	Source string     `json:"source"`
+	Target string     `json:"target"`
+	Steps  []PlanStep `json:"steps"`
+	// Error is the error returned by the mapping, if any
+	Error string `json:"error,omitempty"`
+}
+
+// String prints the plan as a table, e.g:
+//
+//	mapper.User -> mapper.UserDTO
+//	PATH      RESOLUTION        SOURCE
+//	Name      field             Name
+//	FullName  fromMethod        GetFullName
+//	Password  skipped: ignored
+func (p Plan) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%v -> %v\n", p.Source, p.Target)
+
+	var table strings.Builder
+	w := tabwriter.NewWriter(&table, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PATH\tRESOLUTION\tSOURCE")
+	for _, step := range p.Steps {
+		fmt.Fprintf(w, "%v\t%v\t%v\n", step.Path, step.Resolution, step.Source)
+	}
+	w.Flush()
+	// Steps without a source leave trailing spaces
+	for _, line := range strings.SplitAfter(table.String(), "\n") {
+		b.WriteString(strings.TrimRight(strings.TrimSuffix(line, "\n"), " "))
+		if strings.HasSuffix(line, "\n") {
+			b.WriteByte('\n')
+		}
+	}
+
+	if p.Error != "" {
+		fmt.Fprintf(&b, "error: %v\n", p.Error)
+	}
+	return b.String()
+}
+
+// JSON returns the plan as indented JSON
+func (p Plan) JSON() string {
+	data, _ := json.MarshalIndent(p, "", "  ")
+	return string(data)
+}
+
+// resolution is how a target field was resolved, and from which source
+type resolution struct {
+	kind   Resolution
+	source string
+}
+
+// trace records the resolution of the current path when tracing, and returns the index of the step
+func (s *mappingState) trace(r resolution) int {
+	if s.plan == nil {
+		return -1
+	}
+	s.plan.Steps = append(s.plan.Steps, PlanStep{Path: s.currentPath(), Resolution: r.kind, Source: r.source})
+	return len(s.plan.Steps) - 1
+}
+
+// retrace replaces the resolution of a recorded step, e.g. when the resolved value is nil
+func (s *mappingState) retrace(step int, kind Resolution) {
+	if s.plan == nil || step < 0 {
+		return
+	}
+	s.plan.Steps[step].Resolution = kind
+}
+
+// hasUnexportedField reports whether the source struct has an unexported field named like the given one
+// (e.g. `name` for `Name`), to tell apart missing and unexported source fields
+func hasUnexportedField(sourceValue reflect.Value, name string) bool {
+	if sourceValue.Kind() != reflect.Struct || name == "" {
+		return false
+	}
+
+	r, size := utf8.DecodeRuneInString(name)
+	for _, candidate := range []string{name, string(unicode.ToLower(r)) + name[size:]} {
+		if f, ok := sourceValue.Type().FieldByName(candidate); ok && !f.IsExported() {
+			return true
+		}
+	}
+	return false
+}
+
+// Explain returns how the default Mapper resolves each target path when mapping source into target (see Mapper.Explain)
+func Explain(source, target interface{}) Plan {
+	return defaultMapper.Explain(source, target)
+}
+
+// MapWithTrace copies values from source to target (pointer) using the default Mapper, and returns how each
+// target path was resolved along with the error, if any
+func MapWithTrace(source, target interface{}) (Plan, error) {
+	return defaultMapper.MapWithTrace(source, target)
+}
+
+// Explain returns how each target path is resolved when mapping source into target, without modifying target:
+// the source is mapped into a new value of the target type. Note that `fromMethod` methods and hooks are called.
+func (m *Mapper) Explain(source, target interface{}) Plan {
+	if err := validateParameters(source, target); err != nil {
+		return Plan{Source: fmt.Sprintf("%T", source), Target: fmt.Sprintf("%T", target), Error: err.Error()}
+	}
+
+	dryRun := reflect.New(reflect.TypeOf(target).Elem())
+	plan, _ := m.MapWithTrace(source, dryRun.Interface())
+	plan.Target = fmt.Sprintf("%T", target)
+	return plan
+}
+
+// MapWithTrace copies values from source to target (pointer), and returns how each target path was resolved
+// along with the error, if any
+func (m *Mapper) MapWithTrace(source, target interface{}) (Plan, error) {
+	plan := &Plan{Source: fmt.Sprintf("%T", source), Target: fmt.Sprintf("%T", target)}
+	err := m.mapWithState(source, target, &mappingState{ctx: context.Background(), plan: plan})
+	if err != nil {
+		plan.Error = err.Error()
+	}
+	return *plan, err
+}
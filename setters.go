@@ -0,0 +1,86 @@
+package mapper
+
+import (
+	"fmt"
+	"reflect"
+	"unicode"
+	"unicode/utf8"
+)
+
+// setterField is a target field whose value is set by calling a setter method instead of writing it directly
+type setterField struct {
+	field
+	method string
+}
+
+// cachedSetterFields returns the unexported fields of the struct type that have a `Set<Field>` method
+// (e.g. `email` and `SetEmail`), which are set through it following the setter convention
+func (m *Mapper) cachedSetterFields(t reflect.Type) []setterField {
+	if f, ok := m.setterCache.Load(t); ok {
+		return f.([]setterField)
+	}
+
+	var setters []setterField
+	ptrType := reflect.PtrTo(t)
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.IsExported() || sf.Anonymous {
+			continue
+		}
+
+		exportedName := exportName(sf.Name)
+		method := "Set" + exportedName
+		if _, ok := ptrType.MethodByName(method); !ok {
+			continue
+		}
+
+		// Source fields are looked up by the exported name
+		lookup := sf
+		lookup.Name = exportedName
+		setters = append(setters, setterField{
+			field:  field{name: sf.Name, index: sf.Index, typ: sf.Type, structField: lookup},
+			method: method,
+		})
+	}
+
+	f, _ := m.setterCache.LoadOrStore(t, setters)
+	return f.([]setterField)
+}
+
+func exportName(name string) string {
+	r, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToUpper(r)) + name[size:]
+}
+
+// callSetter maps the source value into the type of the setter argument and calls the setter on the target.
+// Setters must receive a single argument, and may return an error.
+func callSetter(targetValue reflect.Value, name string, sourceValue reflect.Value, state *mappingState) error {
+	method := lookupSetter(targetValue, name)
+	if !method.IsValid() {
+		return fmt.Errorf("setter method %v not found in %v", name, targetValue.Type())
+	}
+
+	methodType := method.Type()
+	if methodType.NumIn() != 1 || methodType.NumOut() > 1 || (methodType.NumOut() == 1 && methodType.Out(0) != errorType) {
+		return fmt.Errorf("setter method %v must receive one argument and return nothing or an error", name)
+	}
+
+	arg := reflect.New(methodType.In(0)).Elem()
+	newValue, err := mapValues(sourceValue, arg, state)
+	if err != nil || newValue == nil {
+		return err
+	}
+
+	if out := method.Call([]reflect.Value{arg}); len(out) == 1 && !out[0].IsNil() {
+		return out[0].Interface().(error)
+	}
+
+	return nil
+}
+
+func lookupSetter(targetValue reflect.Value, name string) reflect.Value {
+	if targetValue.CanAddr() {
+		return targetValue.Addr().MethodByName(name)
+	}
+	return targetValue.MethodByName(name)
+}
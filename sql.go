@@ -0,0 +1,111 @@
+package mapper
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var (
+	scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	valuerType  = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+)
+
+// isSQLNull reports whether t belongs to the sql.Null* family (sql.NullString, sql.NullTime, sql.Null[T], etc.),
+// structs with the actual value as their first field and a Valid flag
+func isSQLNull(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct || t.PkgPath() != "database/sql" || !strings.HasPrefix(t.Name(), "Null") {
+		return false
+	}
+
+	valid, ok := t.FieldByName("Valid")
+	return ok && valid.Type.Kind() == reflect.Bool && t.NumField() == 2
+}
+
+// resolveSQLValue maps values into sql.Null* and sql.Scanner targets. It returns false if the target is not one of them
+// (or it has the same type as the source, so it can be copied as usual)
+func resolveSQLValue(sourceValue, targetValue reflect.Value, state *mappingState) (interface{}, bool, error) {
+	if !sourceValue.IsValid() || indirectType(sourceValue.Type()) == targetValue.Type() {
+		return nil, false, nil
+	}
+
+	if isSQLNull(targetValue.Type()) {
+		if sourceValue.Kind() == reflect.Ptr && sourceValue.IsNil() {
+			return nil, true, nil
+		}
+
+		newValue, err := mapValues(sourceValue, targetValue.Field(0), state)
+		if err != nil || newValue == nil {
+			return nil, true, err
+		}
+		targetValue.FieldByName("Valid").SetBool(true)
+
+		return targetValue.Interface(), true, nil
+	}
+
+	if targetValue.CanAddr() && targetValue.Addr().Type().Implements(scannerType) {
+		sourceValue, ok, err := driverValue(sourceValue, targetValue)
+		if err != nil {
+			return nil, true, err
+		}
+
+		var src interface{}
+		if ok && !(sourceValue.Kind() == reflect.Ptr && sourceValue.IsNil()) {
+			src = reflect.Indirect(sourceValue).Interface()
+		}
+
+		// Structs other than time.Time aren't driver values, their fields are mapped as usual
+		if _, isTime := src.(time.Time); reflect.Indirect(sourceValue).Kind() == reflect.Struct && !isTime {
+			return nil, false, nil
+		}
+
+		if err := targetValue.Addr().Interface().(sql.Scanner).Scan(src); err != nil {
+			return nil, true, err
+		}
+
+		return targetValue.Interface(), true, nil
+	}
+
+	return nil, false, nil
+}
+
+// driverValue unwraps sql.Null* and driver.Valuer sources into the value they hold, unless the target has the same type.
+// It returns false when the source holds a NULL value, meaning there's nothing to map.
+func driverValue(sourceValue, targetValue reflect.Value) (reflect.Value, bool, error) {
+	if !sourceValue.IsValid() || (sourceValue.Kind() == reflect.Ptr && sourceValue.IsNil()) {
+		return sourceValue, true, nil
+	}
+
+	sourceType := indirectType(sourceValue.Type())
+	targetType := indirectType(targetValue.Type())
+	if sourceType == targetType {
+		return sourceValue, true, nil
+	}
+
+	if isSQLNull(sourceType) {
+		nullValue := reflect.Indirect(sourceValue)
+		if !nullValue.FieldByName("Valid").Bool() {
+			return reflect.Value{}, false, nil
+		}
+		return nullValue.Field(0), true, nil
+	}
+
+	// Valuer sources are only unwrapped for basic targets (e.g. a string or an int64), otherwise they're
+	// mapped as usual (e.g. the elements of a slice, or the fields of a struct)
+	if !isBasicKind(targetType.Kind()) || sourceValue.Type().AssignableTo(targetValue.Type()) {
+		return sourceValue, true, nil
+	}
+	valuer, ok := implementation(sourceValue, valuerType)
+	if !ok {
+		return sourceValue, true, nil
+	}
+
+	value, err := valuer.(driver.Valuer).Value()
+	if err != nil || value == nil {
+		return reflect.Value{}, false, err
+	}
+
+	return reflect.ValueOf(value), true, nil
+}
@@ -0,0 +1,87 @@
+package mapper
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/fatih/structtag"
+)
+
+// tagSetting is one of the semicolon-separated `option:value` settings of a mapper struct tag
+type tagSetting struct {
+	option string
+	value  string
+}
+
+// mapperTagSettings parses the settings of the mapper struct tag, if any
+func mapperTagSettings(tag reflect.StructTag) []tagSetting {
+	tags, _ := structtag.Parse(string(tag))
+	mapperTag, _ := tags.Get("mapper")
+	if mapperTag == nil {
+		return nil
+	}
+
+	var settings []tagSetting
+	for _, setting := range strings.Split(mapperTag.Value(), ";") {
+		if setting == "" {
+			continue
+		}
+		// Values may contain colons themselves (e.g. a time layout), so only split on the first one
+		parts := strings.SplitN(setting, ":", 2)
+		s := tagSetting{option: parts[0]}
+		if len(parts) > 1 {
+			s.value = parts[1]
+		}
+		settings = append(settings, s)
+	}
+
+	return settings
+}
+
+// lookupTagSetting returns the value of the first setting with the given option
+func lookupTagSetting(settings []tagSetting, option string) (string, bool) {
+	for _, s := range settings {
+		if s.option == option {
+			return s.value, true
+		}
+	}
+	return "", false
+}
+
+// tagName returns the name given to the field by the struct tag with the given key (e.g. `json:"user_name,omitempty"`).
+// It returns the Go name of the field when the tag is missing or has no name (e.g. `json:",omitempty"`),
+// and false when the field is ignored with `-`.
+// gorm tags are read from the `column` setting, and default to the snake_case name just like gorm does.
+func tagName(sf reflect.StructField, key string) (string, bool) {
+	tags, _ := structtag.Parse(string(sf.Tag))
+	tag, _ := tags.Get(key)
+	if key == "gorm" {
+		return gormColumn(tag, sf.Name)
+	}
+	if tag == nil || tag.Name == "" {
+		return sf.Name, true
+	}
+	if tag.Name == "-" && len(tag.Options) == 0 {
+		return "", false
+	}
+
+	return tag.Name, true
+}
+
+// gormColumn returns the column name of a gorm tag, e.g. `gorm:"column:user_name;not null"`
+func gormColumn(tag *structtag.Tag, fieldName string) (string, bool) {
+	if tag == nil {
+		return snakeCase(fieldName), true
+	}
+	if tag.Value() == "-" {
+		return "", false
+	}
+	for _, setting := range strings.Split(tag.Value(), ";") {
+		setting = strings.TrimSpace(setting)
+		if strings.HasPrefix(setting, "column:") && len(setting) > len("column:") {
+			return setting[len("column:"):], true
+		}
+	}
+
+	return snakeCase(fieldName), true
+}
@@ -0,0 +1,99 @@
+package mapper
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+)
+
+var (
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	stringerType        = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	bytesType           = reflect.TypeOf([]byte(nil))
+)
+
+// implementation returns the value itself, or a pointer to a copy of it, if any of them implements iface
+func implementation(value reflect.Value, iface reflect.Type) (interface{}, bool) {
+	if value.Type().Implements(iface) {
+		return value.Interface(), true
+	}
+
+	if reflect.PtrTo(value.Type()).Implements(iface) {
+		ptr := reflect.New(value.Type())
+		ptr.Elem().Set(value)
+		return ptr.Interface(), true
+	}
+
+	return nil, false
+}
+
+// formatText converts a value into a string, preferring encoding.TextMarshaler, then fmt.Stringer
+// and using the default fmt format as the last resort
+func formatText(value reflect.Value) (string, error) {
+	if m, ok := implementation(value, textMarshalerType); ok {
+		text, err := m.(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return "", err
+		}
+		return string(text), nil
+	}
+
+	if s, ok := implementation(value, stringerType); ok {
+		return s.(fmt.Stringer).String(), nil
+	}
+
+	return fmt.Sprintf("%v", value.Interface()), nil
+}
+
+// formattedWithFmt reports whether formatText falls back to the default fmt format for a value that is not
+// a basic type (e.g. a struct), which is rarely the intended text
+func formattedWithFmt(value reflect.Value) bool {
+	if !value.IsValid() || isBasicKind(value.Kind()) {
+		return false
+	}
+	_, marshaler := implementation(value, textMarshalerType)
+	_, stringer := implementation(value, stringerType)
+	return !marshaler && !stringer
+}
+
+// isBasicKind reports whether the kind is a boolean, numeric or string one
+func isBasicKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
+	}
+	return false
+}
+
+// canUnmarshalText reports whether the target should be parsed from the source text,
+// i.e. the source is a string or []byte and the target implements encoding.TextUnmarshaler
+func canUnmarshalText(sourceValue, targetValue reflect.Value) bool {
+	if !sourceValue.IsValid() || !targetValue.IsValid() || sourceValue.Type() == targetValue.Type() || !targetValue.CanAddr() {
+		return false
+	}
+	if sourceValue.Kind() != reflect.String && !sourceValue.Type().ConvertibleTo(bytesType) {
+		return false
+	}
+
+	return targetValue.Addr().Type().Implements(textUnmarshalerType)
+}
+
+func mapFromText(sourceValue, targetValue reflect.Value) (interface{}, error) {
+	var text []byte
+	if sourceValue.Kind() == reflect.String {
+		text = []byte(sourceValue.String())
+	} else {
+		text = sourceValue.Convert(bytesType).Bytes()
+	}
+
+	unmarshaler := targetValue.Addr().Interface().(encoding.TextUnmarshaler)
+	if err := unmarshaler.UnmarshalText(text); err != nil {
+		return nil, err
+	}
+
+	return targetValue.Interface(), nil
+}
@@ -0,0 +1,110 @@
+package mapper
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// TimePolicy describes how time.Time values are copied from source to target.
+// The zero value keeps times as they are, including their monotonic clock reading.
+type TimePolicy struct {
+	// Truncate rounds times down to a multiple of this duration (e.g. time.Millisecond), when positive.
+	// Note that truncating a time strips its monotonic clock reading.
+	Truncate time.Duration
+	// Location converts times to this location (e.g. time.UTC), when not nil
+	Location *time.Location
+}
+
+// Apply returns the given time after applying the policy
+func (p TimePolicy) Apply(t time.Time) time.Time {
+	if p.Truncate > 0 {
+		t = t.Truncate(p.Truncate)
+	}
+	if p.Location != nil {
+		t = t.In(p.Location)
+	}
+	return t
+}
+
+// TimeConverter returns a converter for time.Time targets that applies the given policy. Use it to replace
+// the default behavior, which keeps times as they are:
+//
+//	MapWithConverters(source, &target, map[string]TypeConverterFn{"time.Time": TimeConverter(TimePolicy{Location: time.UTC})})
+func TimeConverter(policy TimePolicy) TypeConverterFn {
+	return func(value interface{}) interface{} {
+		switch v := value.(type) {
+		case time.Time:
+			return policy.Apply(v)
+		case *time.Time:
+			if v == nil {
+				return nil
+			}
+			return policy.Apply(*v)
+		case string:
+			if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+				return policy.Apply(t)
+			}
+		}
+
+		// Anything else can't be converted, and the target is left with its zero value
+		return nil
+	}
+}
+
+// applyTimeTag converts the source value as requested by the time settings of the target field:
+//   - layout:{layout} formats times into strings and parses strings into times with the given layout
+//   - unix / unixMilli converts times into Unix seconds or milliseconds integers and back
+//
+// The returned value is then mapped into the target field as usual
+func applyTimeTag(sourceValue reflect.Value, targetType reflect.Type, settings []tagSetting) (reflect.Value, error) {
+	layout, hasLayout := lookupTagSetting(settings, "layout")
+	_, unix := lookupTagSetting(settings, "unix")
+	_, unixMilli := lookupTagSetting(settings, "unixMilli")
+	if !hasLayout && !unix && !unixMilli {
+		return sourceValue, nil
+	}
+
+	source := reflect.Indirect(sourceValue)
+	if !source.IsValid() || !source.CanInterface() {
+		return sourceValue, nil
+	}
+	targetIsTime := indirectType(targetType) == timeType
+
+	switch {
+	case source.Type() == timeType && !targetIsTime:
+		t := source.Interface().(time.Time)
+		switch {
+		case hasLayout:
+			return reflect.ValueOf(t.Format(layout)), nil
+		case unixMilli:
+			return reflect.ValueOf(t.UnixNano() / int64(time.Millisecond)), nil
+		default:
+			return reflect.ValueOf(t.Unix()), nil
+		}
+	case targetIsTime && hasLayout && source.Kind() == reflect.String:
+		t, err := time.Parse(layout, source.String())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(t), nil
+	case targetIsTime && (unix || unixMilli):
+		var n int64
+		switch source.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			n = source.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			n = int64(source.Uint())
+		default:
+			return reflect.Value{}, fmt.Errorf("cannot convert %v into a Unix time", source.Type())
+		}
+		if unixMilli {
+			return reflect.ValueOf(time.Unix(0, n*int64(time.Millisecond))), nil
+		}
+		return reflect.ValueOf(time.Unix(n, 0)), nil
+	}
+
+	return sourceValue, nil
+}
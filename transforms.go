@@ -0,0 +1,160 @@
+package mapper
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TransformFn receives a source value and the arguments given in the tag (e.g. `truncate(10)`),
+// and returns the transformed value
+type TransformFn func(value interface{}, args ...string) (interface{}, error)
+
+// builtinTransforms are available in every Mapper
+var builtinTransforms = map[string]TransformFn{
+	"trim":     stringTransform(strings.TrimSpace),
+	"lower":    stringTransform(strings.ToLower),
+	"upper":    stringTransform(strings.ToUpper),
+	"title":    stringTransform(title),
+	"truncate": truncate,
+}
+
+// RegisterTransform registers a named transform to be used in the mapper tag, e.g. `mapper:"transform:slug"`.
+// Registering a transform with the name of an existing one replaces it.
+func RegisterTransform(name string, fn TransformFn) {
+	defaultMapper.RegisterTransform(name, fn)
+}
+
+// RegisterTransform registers a named transform to be used in the mapper tag, e.g. `mapper:"transform:slug"`.
+// Registering a transform with the name of an existing one replaces it.
+func (m *Mapper) RegisterTransform(name string, fn TransformFn) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.transforms[name] = fn
+}
+
+func (m *Mapper) lookupTransform(name string) (TransformFn, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	fn, ok := m.transforms[name]
+	return fn, ok
+}
+
+// applyTransforms runs the source value through the transforms listed in the `transform` setting of the target
+// field, in order. E.g: `mapper:"fromField:Email;transform:trim,lower"`
+func applyTransforms(sourceValue reflect.Value, settings []tagSetting, state *mappingState) (reflect.Value, error) {
+	pipeline, ok := lookupTagSetting(settings, "transform")
+	if !ok {
+		return sourceValue, nil
+	}
+
+	// missing and nil values are left as they are, there's nothing to transform
+	if !sourceValue.IsValid() || !sourceValue.CanInterface() || (sourceValue.Kind() == reflect.Ptr && sourceValue.IsNil()) {
+		return sourceValue, nil
+	}
+	value := reflect.Indirect(sourceValue).Interface()
+
+	for _, call := range splitTransforms(pipeline) {
+		name, args, err := parseTransformCall(call)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		fn, ok := state.mapper.lookupTransform(name)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("%v: %w", name, ErrUnknownTransform)
+		}
+
+		if value, err = fn(value, args...); err != nil {
+			return reflect.Value{}, fmt.Errorf("transform %v: %w", name, err)
+		}
+	}
+
+	return reflect.ValueOf(value), nil
+}
+
+// splitTransforms splits a comma-separated pipeline, ignoring the commas inside the arguments of a call
+func splitTransforms(pipeline string) []string {
+	var calls []string
+	depth, start := 0, 0
+	for i, r := range pipeline {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				calls = append(calls, strings.TrimSpace(pipeline[start:i]))
+				start = i + 1
+			}
+		}
+	}
+
+	return append(calls, strings.TrimSpace(pipeline[start:]))
+}
+
+// parseTransformCall parses either `name` or `name(arg1,arg2)`
+func parseTransformCall(call string) (string, []string, error) {
+	open := strings.IndexByte(call, '(')
+	if open < 0 {
+		return call, nil, nil
+	}
+	if !strings.HasSuffix(call, ")") {
+		return "", nil, fmt.Errorf("invalid transform: %v", call)
+	}
+
+	var args []string
+	if inner := call[open+1 : len(call)-1]; inner != "" {
+		for _, arg := range strings.Split(inner, ",") {
+			args = append(args, strings.TrimSpace(arg))
+		}
+	}
+
+	return call[:open], args, nil
+}
+
+// stringTransform builds a transform from a string function. It accepts any value of a string kind,
+// and returns a value of the same type
+func stringTransform(fn func(string) string) TransformFn {
+	return func(value interface{}, args ...string) (interface{}, error) {
+		v := reflect.ValueOf(value)
+		if v.Kind() != reflect.String {
+			return nil, fmt.Errorf("cannot transform %T, a string is expected", value)
+		}
+
+		return reflect.ValueOf(fn(v.String())).Convert(v.Type()).Interface(), nil
+	}
+}
+
+func title(s string) string {
+	previous := ' '
+	return strings.Map(func(r rune) rune {
+		defer func() { previous = r }()
+		if unicode.IsSpace(previous) {
+			return unicode.ToTitle(r)
+		}
+		return r
+	}, s)
+}
+
+// truncate keeps the first n characters of a string, e.g. `truncate(10)`
+func truncate(value interface{}, args ...string) (interface{}, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("truncate expects 1 argument, got %d", len(args))
+	}
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n < 0 {
+		return nil, fmt.Errorf("invalid truncate length: %v", args[0])
+	}
+
+	return stringTransform(func(s string) string {
+		if utf8.RuneCountInString(s) <= n {
+			return s
+		}
+		return string([]rune(s)[:n])
+	})(value)
+}
@@ -0,0 +1,435 @@
+package mapper
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// TypePair is a source and target type to be validated with Validate
+type TypePair struct {
+	Source reflect.Type
+	Target reflect.Type
+}
+
+// Pair returns the TypePair for mapping Src values into Dst values
+func Pair[Src, Dst any]() TypePair {
+	return TypePair{
+		Source: reflect.TypeOf((*Src)(nil)).Elem(),
+		Target: reflect.TypeOf((*Dst)(nil)).Elem(),
+	}
+}
+
+func (p TypePair) String() string {
+	return fmt.Sprintf("%v -> %v", p.Source, p.Target)
+}
+
+// ValidationError lists the problems found by Validate
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("mapper: %d validation problem(s):\n  %v", len(e.Problems), strings.Join(e.Problems, "\n  "))
+}
+
+var knownTagOptions = map[string]bool{
+	"fromField": true, "toField": true, "fromMethod": true, "toMethod": true,
+	"layout": true, "unix": true, "unixMilli": true, "transform": true, "reverse": true,
+}
+
+// Validate checks the given type pairs, and the ones configured in the default Mapper, up front (see Mapper.Validate)
+func Validate(pairs ...TypePair) error {
+	return defaultMapper.Validate(pairs...)
+}
+
+// Validate checks the given type pairs, and the ones configured in the Mapper, up front. It reports:
+//   - unknown tag options and transforms
+//   - missing source fields and methods
+//   - methods with the wrong signature
+//   - incompatible types without converters
+//   - unmapped target fields (unless they're ignored)
+//
+// Nested struct types are validated too. It returns a *ValidationError listing all the problems, if any, e.g:
+//
+//	func TestMappings(t *testing.T) {
+//		assert.Nil(t, mapper.Validate(mapper.Pair[User, UserDTO]()))
+//	}
+func (m *Mapper) Validate(pairs ...TypePair) error {
+	v := &validation{mapper: m, visited: map[TypePair]bool{}}
+	for _, pair := range append(m.configuredPairs(), pairs...) {
+		v.validatePair(TypePair{indirectType(pair.Source), indirectType(pair.Target)})
+	}
+
+	if len(v.problems) > 0 {
+		return &ValidationError{Problems: v.problems}
+	}
+	return nil
+}
+
+func (m *Mapper) configuredPairs() []TypePair {
+	m.config.mu.RLock()
+	defer m.config.mu.RUnlock()
+
+	pairs := make([]TypePair, 0, len(m.config.typeMaps))
+	for pair := range m.config.typeMaps {
+		pairs = append(pairs, TypePair{pair.source, pair.target})
+	}
+	// Keep the problems in a stable order
+	sort.Slice(pairs, func(i, j int) bool {
+		return pairs[i].String() < pairs[j].String()
+	})
+	return pairs
+}
+
+type validation struct {
+	mapper   *Mapper
+	visited  map[TypePair]bool
+	problems []string
+}
+
+func (v *validation) report(pair TypePair, fieldName, format string, args ...interface{}) {
+	v.problems = append(v.problems, fmt.Sprintf("%v: %v: %v", pair, fieldName, fmt.Sprintf(format, args...)))
+}
+
+func (v *validation) validatePair(pair TypePair) {
+	if v.visited[pair] {
+		return
+	}
+	v.visited[pair] = true
+
+	if pair.Source.Kind() != reflect.Struct || pair.Target.Kind() != reflect.Struct {
+		v.checkTypes(pair, "", pair.Source, pair.Target)
+		return
+	}
+
+	m := v.mapper
+	// Source fields may be tagged too, e.g. with toField
+	for _, f := range m.cachedTypeFields(pair.Source).list {
+		for _, setting := range mapperTagSettings(f.structField.Tag) {
+			if !knownTagOptions[setting.option] {
+				v.report(pair, f.name, "unknown tag option %q in source field", setting.option)
+			}
+		}
+	}
+
+	typeMap := m.config.lookup(pair.Source, pair.Target)
+	var wholeEmbedded [][]int
+	for _, f := range m.cachedTypeFields(pair.Target).list {
+		// Embedded structs are validated like Map maps them: as a whole, or through their promoted fields
+		if withinAny(f.index, wholeEmbedded) {
+			continue
+		}
+		if f.embedded {
+			if !m.embeddedMappedWhole(pair.Source, f, m.hasConverter) {
+				continue
+			}
+			wholeEmbedded = append(wholeEmbedded, f.index)
+		}
+
+		settings, ignored := typeMap.fieldSettings(f)
+		if _, ok := m.targetMatchName(f); ignored || !ok {
+			continue
+		}
+		v.validateField(pair, f, f.typ, settings)
+	}
+
+	for _, setter := range m.cachedSetterFields(pair.Target) {
+		settings, ignored := typeMap.fieldSettings(setter.field)
+		if _, ok := m.targetMatchName(setter.field); ignored || !ok {
+			continue
+		}
+		method, _ := reflect.PtrTo(pair.Target).MethodByName(setter.method)
+		if argType, ok := setterArgType(method.Type); ok {
+			v.validateField(pair, setter.field, argType, settings)
+		} else {
+			v.report(pair, setter.name, "setter method %v must receive one argument and return nothing or an error", setter.method)
+		}
+	}
+
+	paths, nested := typeMap.nestedFields()
+	for _, path := range paths {
+		if f, ok := v.fieldByPath(pair.Target, path); ok {
+			f.name = path
+			v.validateField(pair, f, f.typ, nested[path])
+		}
+	}
+
+	for _, call := range typeMap.setterCalls() {
+		method, ok := reflect.PtrTo(pair.Target).MethodByName(call.method)
+		argType, valid := setterArgType(method.Type)
+		switch {
+		case !ok:
+			v.report(pair, call.field, "setter method %v not found in %v", call.method, pair.Target)
+		case !valid:
+			v.report(pair, call.field, "setter method %v must receive one argument and return nothing or an error", call.method)
+		default:
+			if sourceField, ok := v.fieldByPath(pair.Source, call.field); ok {
+				v.checkTypes(pair, call.field, sourceField.typ, argType)
+			} else {
+				v.report(pair, call.field, "source field %v not found in %v", call.field, pair.Source)
+			}
+		}
+	}
+}
+
+// validateField checks the settings of a target field and the source it's mapped from
+func (v *validation) validateField(pair TypePair, f field, targetType reflect.Type, settings []tagSetting) {
+	m := v.mapper
+	converted := false
+	for _, setting := range settings {
+		if !knownTagOptions[setting.option] {
+			v.report(pair, f.name, "unknown tag option %q", setting.option)
+		}
+		switch setting.option {
+		case "layout", "unix", "unixMilli":
+			converted = true
+		case "transform":
+			converted = true
+			for _, call := range splitTransforms(setting.value) {
+				name, _, err := parseTransformCall(call)
+				if err != nil {
+					v.report(pair, f.name, "%v", err)
+				} else if _, ok := m.lookupTransform(name); !ok {
+					v.report(pair, f.name, "unknown transform %q", name)
+				}
+			}
+		case "toMethod":
+			method, ok := reflect.PtrTo(pair.Target).MethodByName(setting.value)
+			if argType, valid := setterArgType(method.Type); !ok {
+				v.report(pair, f.name, "setter method %v not found in %v", setting.value, pair.Target)
+			} else if !valid {
+				v.report(pair, f.name, "setter method %v must receive one argument and return nothing or an error", setting.value)
+			} else {
+				targetType = argType
+			}
+		}
+	}
+
+	sourceType, ok := v.sourceType(pair, f, settings)
+	if !ok || converted {
+		return
+	}
+	v.checkTypes(pair, f.name, sourceType, targetType)
+}
+
+// sourceType returns the type of the value the target field is mapped from, following the same rules as
+// getSourceFieldValue. It reports the problem and returns false if there's no valid source.
+func (v *validation) sourceType(pair TypePair, f field, settings []tagSetting) (reflect.Type, bool) {
+	m := v.mapper
+	for _, setting := range settings {
+		switch setting.option {
+		case "fromField", "toField":
+			sourceField, ok := v.fieldByPath(pair.Source, setting.value)
+			if !ok {
+				v.report(pair, f.name, "source field %v not found in %v", setting.value, pair.Source)
+			}
+			return sourceField.typ, ok
+		case "fromMethod":
+			return v.methodResultType(pair, f.name, setting.value)
+		}
+	}
+
+	name, _ := m.sourceMatchName(pair.Source, f)
+	fields := m.matchFields(pair.Source)
+	if i, ok := fields.byName[name]; ok {
+		return fields.list[i].typ, true
+	}
+	if rename, ok := m.renamedSourceFields(pair.Source)[f.structField.Name]; ok {
+		if rename.err != nil {
+			v.report(pair, f.name, "%v", rename.err)
+			return nil, false
+		}
+		sourceField, _ := m.fieldNamed(pair.Source, rename.name)
+		return sourceField.typ, true
+	}
+	match, err := m.resolveFieldName(pair.Source, name)
+	if err != nil {
+		v.report(pair, f.name, "%v", err)
+		return nil, false
+	}
+	if i, ok := fields.byName[match]; ok && match != "" {
+		return fields.list[i].typ, true
+	}
+
+	if m.getters {
+		for _, getter := range []string{"Get" + f.structField.Name, f.structField.Name} {
+			if method, ok := lookupMethodType(pair.Source, getter); ok {
+				if t, ok := getterResultType(method.Type); ok {
+					return t, true
+				}
+			}
+		}
+	}
+
+	v.report(pair, f.name, "unmapped target field, no source field found in %v", pair.Source)
+	return nil, false
+}
+
+// methodResultType checks a `fromMethod` call against the method signature, and returns the type of its result
+func (v *validation) methodResultType(pair TypePair, fieldName, call string) (reflect.Type, bool) {
+	name, literals, err := parseTransformCall(call)
+	if err != nil {
+		v.report(pair, fieldName, "%v", err)
+		return nil, false
+	}
+
+	method, ok := lookupMethodType(pair.Source, name)
+	if !ok {
+		v.report(pair, fieldName, "source method %v not found in %v", name, pair.Source)
+		return nil, false
+	}
+
+	methodType := method.Type
+	if methodType.IsVariadic() {
+		v.report(pair, fieldName, "method %v: variadic methods are not supported", name)
+		return nil, false
+	}
+
+	// The first argument is the receiver
+	args := 0
+	for i := 1; i < methodType.NumIn(); i++ {
+		if in := methodType.In(i); in != contextType {
+			if args < len(literals) {
+				if _, err := parseLiteral(literals[args], in); err != nil {
+					v.report(pair, fieldName, "method %v: %v", name, err)
+				}
+			}
+			args++
+		}
+	}
+	if args != len(literals) {
+		v.report(pair, fieldName, "method %v receives %d argument(s), %d given", name, args, len(literals))
+		return nil, false
+	}
+
+	if methodType.NumOut() == 0 || (methodType.NumOut() == 1 && methodType.Out(0) == errorType) {
+		v.report(pair, fieldName, "method %v must return a value", name)
+		return nil, false
+	}
+	return methodType.Out(0), true
+}
+
+// checkTypes reports the source and target types that can't be mapped, and validates nested struct types
+func (v *validation) checkTypes(pair TypePair, fieldName string, source, target reflect.Type) {
+	if !v.compatible(source, target) {
+		v.report(pair, fieldName, "cannot map %v into %v without a converter", source, target)
+	}
+}
+
+// compatible reports whether source values can be mapped into target values, following the same rules as mapValues
+func (v *validation) compatible(source, target reflect.Type) bool {
+	m := v.mapper
+	if _, ok := m.converters[target.String()]; ok {
+		return true
+	}
+	if _, ok := m.contextConverters[target.String()]; ok {
+		return true
+	}
+	if source.Kind() == reflect.Interface {
+		// The dynamic type is only known at run-time
+		return true
+	}
+	if _, ok := m.lookupEnumTable(indirectType(source), target); ok {
+		return true
+	}
+	if isSQLNull(indirectType(source)) || isSQLNull(indirectType(target)) ||
+		source.Implements(valuerType) || reflect.PtrTo(indirectType(target)).Implements(scannerType) {
+		return true
+	}
+	if reflect.PtrTo(indirectType(target)).Implements(textUnmarshalerType) &&
+		(indirectType(source).Kind() == reflect.String || indirectType(source) == bytesType) {
+		return true
+	}
+
+	switch target.Kind() {
+	case reflect.Ptr:
+		return v.compatible(indirectType(source), target.Elem())
+	case reflect.String:
+		return true
+	case reflect.Interface:
+		if _, ok := m.lookupInterfaceResolver(target, source); ok {
+			return true
+		}
+		return source.AssignableTo(target)
+	}
+
+	source = indirectType(source)
+	switch {
+	case source.AssignableTo(target):
+		return true
+	case target.Kind() == reflect.Struct && source.Kind() == reflect.Struct:
+		v.validatePair(TypePair{source, target})
+		return true
+	case target.Kind() == reflect.Slice && source.Kind() == reflect.Slice:
+		return v.compatible(source.Elem(), target.Elem())
+	case target.Kind() == reflect.Map && source.Kind() == reflect.Map:
+		return v.compatible(source.Key(), target.Key()) && v.compatible(source.Elem(), target.Elem())
+	case target.Kind() == reflect.Struct || target.Kind() == reflect.Slice || target.Kind() == reflect.Map:
+		return false
+	}
+	return source.ConvertibleTo(target)
+}
+
+// fieldByPath returns the (possibly promoted) field of the struct type with the given dotted path
+func (v *validation) fieldByPath(t reflect.Type, path string) (field, bool) {
+	var f field
+	for _, name := range strings.Split(path, ".") {
+		t = indirectType(t)
+		if t.Kind() != reflect.Struct {
+			return field{}, false
+		}
+		var ok bool
+		if f, ok = v.mapper.fieldNamed(t, name); !ok {
+			return field{}, false
+		}
+		t = f.typ
+	}
+	return f, true
+}
+
+// lookupMethodType finds the method by name on the type, or on a pointer to it
+func lookupMethodType(t reflect.Type, name string) (reflect.Method, bool) {
+	if method, ok := t.MethodByName(name); ok {
+		return method, true
+	}
+	if t.Kind() != reflect.Ptr {
+		return reflect.PtrTo(t).MethodByName(name)
+	}
+	return reflect.Method{}, false
+}
+
+// setterArgType returns the argument type of a setter method (including the receiver),
+// which must receive one argument and return nothing or an error
+func setterArgType(methodType reflect.Type) (reflect.Type, bool) {
+	if methodType == nil || methodType.NumIn() != 2 || methodType.NumOut() > 1 ||
+		(methodType.NumOut() == 1 && methodType.Out(0) != errorType) {
+		return nil, false
+	}
+	return methodType.In(1), true
+}
+
+// getterResultType returns the result type of a getter method (including the receiver),
+// which must receive no arguments (other than a context.Context) and return at least one value
+func getterResultType(methodType reflect.Type) (reflect.Type, bool) {
+	for i := 1; i < methodType.NumIn(); i++ {
+		if methodType.In(i) != contextType {
+			return nil, false
+		}
+	}
+	if methodType.NumOut() == 0 {
+		return nil, false
+	}
+	return methodType.Out(0), true
+}
+
+// hasConverter reports whether the Mapper has a converter for the target type
+func (m *Mapper) hasConverter(targetType reflect.Type) bool {
+	name := targetType.String()
+	if _, ok := m.converters[name]; ok {
+		return true
+	}
+	_, ok := m.contextConverters[name]
+	return ok
+}